@@ -1,72 +1,76 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
+	"embed"
 	"fmt"
 	"strconv"
-	"strings"
 	"unicode"
 
 	"github.com/Kris-Pelteshki/aoc_2023/cast"
 	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(i string) (total int) {
-	for _, line := range util.SplitLines(i) {
-		first, last := findDigits(line)
-		total += cast.ToInt(first + last)
+	for _, value := range cast.Must(calibrationValues(i, nil)) {
+		total += value
 	}
 	return total
 }
 
+var spelledDigits = map[string]int{
+	"one":   1,
+	"two":   2,
+	"three": 3,
+	"four":  4,
+	"five":  5,
+	"six":   6,
+	"seven": 7,
+	"eight": 8,
+	"nine":  9,
+}
+
 func part2(i string) (total int) {
-	digits := map[string]int{
-		"one":   1,
-		"two":   2,
-		"three": 3,
-		"four":  4,
-		"five":  5,
-		"six":   6,
-		"seven": 7,
-		"eight": 8,
-		"nine":  9,
+	for _, value := range cast.Must(calibrationValues(i, spelledDigits)) {
+		total += value
 	}
+	return total
+}
 
-	for _, line := range util.SplitLines(i) {
+// calibrationValues reads the first and last digit of each line as a number,
+// with digits also spelled out as the words in the digits map
+func calibrationValues(i string, digits map[string]int) (values []int, err error) {
+	for n, line := range util.SplitLines(i) {
 		first, _ := findDigits(frontReplace(line, &digits))
 		_, last := findDigits(backReplace(line, &digits))
-		total += cast.ToInt(first + last)
+		if first == "" {
+			return nil, fmt.Errorf("line %d: no digits in %q", n+1, line)
+		}
+		value, err := cast.Parse[int](first + last)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		values = append(values, value)
 	}
-	return total
+	return values, nil
 }
 
 func frontReplace(line string, digits *map[string]int) string {
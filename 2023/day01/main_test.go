@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example1 = `1abc2
@@ -61,3 +63,18 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzCalibrationValues(f *testing.F) {
+	f.Add(example1)
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, func(input string) ([]int, error) {
+			return calibrationValues(input, spelledDigits)
+		})
+	})
+}
@@ -1,14 +1,14 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
+	"embed"
 	"fmt"
-	"strings"
-	"time"
 
 	"github.com/Kris-Pelteshki/aoc_2023/cast"
-	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/scan"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
 type GameValues struct {
@@ -53,38 +53,24 @@ func (g *Game) isPossible() bool {
 	return true
 }
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-	startTime := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-	fmt.Println("Time", time.Since(startTime))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) (total int) {
-	games := parseInput(input)
+	games := cast.Must(parseInput(input))
 
 	for _, game := range games {
 		if game.isPossible() {
@@ -96,7 +82,7 @@ func part1(input string) (total int) {
 }
 
 func part2(input string) (total int) {
-	games := parseInput(input)
+	games := cast.Must(parseInput(input))
 
 	for _, game := range games {
 		maxValues := game.getMaxOfEachColor()
@@ -106,36 +92,39 @@ func part2(input string) (total int) {
 	return total
 }
 
-func parseInput(input string) (games []*Game) {
-	for _, line := range strings.Split(input, "\n") {
-		game := new(Game)
+type cubeCount struct {
+	Count int
+	Color string
+}
 
-		gameStr, dataStr, found := strings.Cut(line, ":")
-		if !found {
-			panic("no colon in line")
-		}
+type gameRecord struct {
+	ID    int           `prefix:"Game" end:":"`
+	Draws [][]cubeCount `sep:";" sep2:","`
+}
 
-		_, err := fmt.Sscanf(gameStr, "Game %d", &game.id)
-		if err != nil {
-			panic(err)
-		}
+func parseInput(input string) (games []*Game, err error) {
+	var records []gameRecord
+	if err := scan.Unmarshal(input, &records); err != nil {
+		return nil, err
+	}
 
-		for _, resultString := range strings.Split(dataStr, ";") {
+	for i, record := range records {
+		game := &Game{id: record.ID}
+
+		for _, draw := range record.Draws {
 			result := GameValues{}
 			colors := map[string]*int{
 				"red":   &result.red,
 				"green": &result.green,
 				"blue":  &result.blue,
 			}
-			values := strings.Split(resultString, ",")
 
-			for _, value := range values {
-				num, color, found := strings.Cut(strings.TrimSpace(value), " ")
-				if !found {
-					panic("no space in value")
+			for _, cubes := range draw {
+				count, ok := colors[cubes.Color]
+				if !ok {
+					return nil, fmt.Errorf("line %d: unknown color %q", i+1, cubes.Color)
 				}
-
-				*colors[color] = cast.ToInt(num)
+				*count = cubes.Count
 			}
 
 			game.results = append(game.results, result)
@@ -143,5 +132,5 @@ func parseInput(input string) (games []*Game) {
 
 		games = append(games, game)
 	}
-	return games
+	return games, nil
 }
@@ -2,6 +2,8 @@ package main
 
 import (
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example = `Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green
@@ -61,3 +63,15 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, parseInput)
+	})
+}
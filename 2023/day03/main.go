@@ -1,16 +1,16 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
+	"embed"
 	"fmt"
 	"slices"
 	"strings"
-	"time"
 	"unicode"
 
 	"github.com/Kris-Pelteshki/aoc_2023/cast"
-	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
 type Point[T any] struct {
@@ -28,6 +28,9 @@ type numbersCoordMap = map[coordKey][]coordKey
 type symbolMap = map[coordKey]*Point[rune]
 type numbersMap = map[coordKey]*Point[int]
 
+// digitOwnerMap is the reverse of numbersCoordMap, from each digit to its number
+type digitOwnerMap = map[coordKey]coordKey
+
 type Grid struct {
 	rows []string
 }
@@ -36,39 +39,27 @@ func isSymbol(r rune) bool {
 	return r != '.' && !unicode.IsDigit(r)
 }
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	startTime := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-	fmt.Printf("Time taken: %v\n", time.Since(startTime))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) (total int) {
-	symbolMap, numbersMap, coordsMap := buildMaps(&input)
+	symbolMap, numbersMap, coordsMap, err := buildMaps(&input)
+	if err != nil {
+		panic(err)
+	}
 
 	for _, point := range numbersMap {
 		if hasAdjacentSymbol(&symbolMap, &coordsMap, point) {
@@ -79,7 +70,10 @@ func part1(input string) (total int) {
 }
 
 func part2(input string) (total int) {
-	symbolMap, numbersMap, coordsMap := buildMaps(&input)
+	symbolMap, numbersMap, coordsMap, err := buildMaps(&input)
+	if err != nil {
+		panic(err)
+	}
 
 	for _, point := range symbolMap {
 		if point.value != '*' {
@@ -87,8 +81,9 @@ func part2(input string) (total int) {
 		}
 	}
 
+	digitOwners := getDigitOwners(&coordsMap)
 	for _, symbol := range symbolMap {
-		num1, num2, ok := getTwoAdjacentNumbers(&numbersMap, &coordsMap, symbol)
+		num1, num2, ok := getTwoAdjacentNumbers(&numbersMap, &digitOwners, symbol)
 		if ok {
 			total += num1 * num2
 		}
@@ -96,7 +91,7 @@ func part2(input string) (total int) {
 	return total
 }
 
-func buildMaps(input *string) (symbols symbolMap, numbers numbersMap, coordsMap numbersCoordMap) {
+func buildMaps(input *string) (symbols symbolMap, numbers numbersMap, coordsMap numbersCoordMap, err error) {
 	grid := &Grid{
 		rows: strings.Split(*input, "\n"),
 	}
@@ -121,7 +116,11 @@ func buildMaps(input *string) (symbols symbolMap, numbers numbersMap, coordsMap
 					temp += string(char)
 				}
 
-				point := Point[int]{cast.ToInt(temp), x, y}
+				value, err := cast.Parse[int](temp)
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("line %d: %w", y+1, err)
+				}
+				point := Point[int]{value, x, y}
 				numbers[point.getCoords()] = &point
 				coordsMap[point.getCoords()] = coords
 
@@ -137,7 +136,7 @@ func buildMaps(input *string) (symbols symbolMap, numbers numbersMap, coordsMap
 		}
 	}
 
-	return symbols, numbers, coordsMap
+	return symbols, numbers, coordsMap, nil
 }
 
 func hasAdjacentSymbol[Tpoint any](symbolMap *symbolMap, coordsMap *numbersCoordMap, p *Point[Tpoint]) bool {
@@ -169,21 +168,21 @@ func hasAdjacentSymbol[Tpoint any](symbolMap *symbolMap, coordsMap *numbersCoord
 	return found
 }
 
-func getTwoAdjacentNumbers[Tpoint any](numbersMap *numbersMap, coordsMap *numbersCoordMap, p *Point[Tpoint]) (int, int, bool) {
-	adjacentNums := []int{}
-	seenNumIds := []coordKey{}
-
-	getNumberCoords := func(x, y int) (coordKey, bool) {
-		for key, coords := range *coordsMap {
-			for _, coord := range coords {
-				px, py := coord[0], coord[1]
-				if px == x && py == y {
-					return key, true
-				}
-			}
+// getDigitOwners indexes which number every digit belongs to, so finding the
+// numbers around a gear doesn't search every number
+func getDigitOwners(coordsMap *numbersCoordMap) digitOwnerMap {
+	owners := make(digitOwnerMap)
+	for key, coords := range *coordsMap {
+		for _, coord := range coords {
+			owners[coord] = key
 		}
-		return coordKey{x, y}, false
 	}
+	return owners
+}
+
+func getTwoAdjacentNumbers[Tpoint any](numbersMap *numbersMap, digitOwners *digitOwnerMap, p *Point[Tpoint]) (int, int, bool) {
+	adjacentNums := []int{}
+	seenNumIds := []coordKey{}
 
 	for y := p.y - 1; y <= p.y+1; y++ {
 		for x := p.x - 1; x <= p.x+1; x++ {
@@ -191,9 +190,9 @@ func getTwoAdjacentNumbers[Tpoint any](numbersMap *numbersMap, coordsMap *number
 				continue
 			}
 
-			numKey, found := getNumberCoords(x, y)
+			numKey, found := (*digitOwners)[coordKey{x, y}]
 
-			if found && slices.Contains(seenNumIds, numKey) {
+			if !found || slices.Contains(seenNumIds, numKey) {
 				continue
 			}
 
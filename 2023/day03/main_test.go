@@ -1,7 +1,12 @@
 package main
 
 import (
+	"math/rand"
+	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example = `467..114..
@@ -82,3 +87,49 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzBuildMaps(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, func(input string) (numbersMap, error) {
+			_, numbers, _, err := buildMaps(&input)
+			return numbers, err
+		})
+	})
+}
+
+// randomSchematic makes a size x size schematic of numbers up to three digits
+// with a dot after each, and symbols, mostly gears, between them
+func randomSchematic(r *rand.Rand, size int) string {
+	const symbols = "****#$+/=%@&-"
+	rows := make([]string, size)
+	for y := range rows {
+		var row strings.Builder
+		for row.Len() < size {
+			switch n := r.Intn(10); {
+			case n < 3:
+				row.WriteString(strconv.Itoa(1+r.Intn(999)) + ".")
+			case n < 4:
+				row.WriteByte(symbols[r.Intn(len(symbols))])
+			default:
+				row.WriteByte('.')
+			}
+		}
+		rows[y] = row.String()[:size]
+	}
+	return strings.Join(rows, "\n")
+}
+
+func Benchmark_scaling(b *testing.B) {
+	b.Run("part1", func(b *testing.B) {
+		harness.Scaling(b, randomSchematic, part1, harness.Linear, 20, 40, 80, 160)
+	})
+	b.Run("part2", func(b *testing.B) {
+		harness.Scaling(b, randomSchematic, part2, harness.Linear, 20, 40, 80, 160)
+	})
+}
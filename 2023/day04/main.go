@@ -1,27 +1,26 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
-	"fmt"
+	"embed"
 	"math"
 	"slices"
-	"strings"
-	"time"
 
 	"github.com/Kris-Pelteshki/aoc_2023/cast"
-	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/scan"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
 type Card struct {
-	id               int
-	potentialWinners []int
-	nums             []int
+	ID               int   `prefix:"Card" end:":"`
+	PotentialWinners []int `end:"|"`
+	Nums             []int
 }
 
 func (c *Card) getWinningNumbers() (winners []int) {
-	for _, num := range c.nums {
-		if slices.Contains(c.potentialWinners, num) {
+	for _, num := range c.Nums {
+		if slices.Contains(c.PotentialWinners, num) {
 			winners = append(winners, num)
 		}
 	}
@@ -39,40 +38,24 @@ func cardScore(amountOfWinningNumbers int) (score int) {
 	return int(math.Pow(2, float64(exponent)))
 }
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-
-	start := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-
-	fmt.Println("Time:", time.Since(start))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) (total int) {
-	cards := parseInput(input)
+	cards := cast.Must(parseInput(input))
 
 	for _, c := range cards {
 		winners := c.getWinningNumbers()
@@ -83,11 +66,11 @@ func part1(input string) (total int) {
 }
 
 func part2(input string) (total int) {
-	cards := parseInput(input)
+	cards := cast.Must(parseInput(input))
 	cardCountMap := make(map[int]int)
 
 	for _, c := range cards {
-		count, hasCount := cardCountMap[c.id]
+		count, hasCount := cardCountMap[c.ID]
 
 		if hasCount {
 			count++
@@ -97,7 +80,7 @@ func part2(input string) (total int) {
 
 		winnersLen := len(c.getWinningNumbers())
 		for i := 0; i < winnersLen; i++ {
-			next := c.id + i + 1
+			next := c.ID + i + 1
 			cardCountMap[next] += count
 		}
 
@@ -107,17 +90,7 @@ func part2(input string) (total int) {
 	return total
 }
 
-func parseInput(input string) (cards []*Card) {
-	for _, line := range util.SplitLines(input) {
-		c := Card{}
-		first, second, _ := strings.Cut(line, ":")
-		fmt.Sscanf(first, "Card %d", &c.id)
-
-		winStr, numStr, _ := strings.Cut(second, "|")
-		c.potentialWinners = cast.ToInts(strings.Fields(winStr))
-		c.nums = cast.ToInts(strings.Fields(numStr))
-
-		cards = append(cards, &c)
-	}
-	return cards
+func parseInput(input string) (cards []*Card, err error) {
+	err = scan.Unmarshal(input, &cards)
+	return cards, err
 }
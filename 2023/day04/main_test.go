@@ -2,6 +2,8 @@ package main
 
 import (
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example = `Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53
@@ -62,3 +64,15 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, parseInput)
+	})
+}
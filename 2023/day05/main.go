@@ -1,17 +1,19 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
+	"embed"
+	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/Kris-Pelteshki/aoc_2023/cast"
-	"github.com/Kris-Pelteshki/aoc_2023/util"
 	"github.com/Kris-Pelteshki/aoc_2023/util/collections"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
 	"github.com/Kris-Pelteshki/aoc_2023/util/maths"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 	"github.com/emirpasic/gods/stacks/arraystack"
 )
 
@@ -117,44 +119,29 @@ func (cm *ConversionMap) mapIntervals(intervals []Interval) []Interval {
 	return result
 }
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-
-	fmt.Println("Time:", time.Since(start))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) uint {
-	seeds, maps := parseInput(input)
+	almanac := cast.Must(parseInput(input))
 	lowestLocations := []uint{}
 
-	for _, seed := range seeds {
+	for _, seed := range almanac.seeds {
 		ans := seed
-		for _, convMap := range maps {
+		for _, convMap := range almanac.maps {
 			ans = convMap.mapValue(ans)
 		}
 		lowestLocations = append(lowestLocations, ans)
@@ -163,17 +150,36 @@ func part1(input string) uint {
 	return maths.Min(lowestLocations...)
 }
 
+// seedRanges reads the seeds as part 2 does, in pairs of a start and a length.
+func seedRanges(seeds []uint) ([]Interval, error) {
+	if len(seeds)%2 != 0 {
+		return nil, fmt.Errorf("want seeds in pairs, got %d seeds", len(seeds))
+	}
+	var ranges []Interval
+	for _, seedPair := range collections.Chunks(seeds, 2) {
+		start, length := seedPair[0], seedPair[1]
+		if length == 0 {
+			return nil, fmt.Errorf("seed range from %d is empty", start)
+		}
+		end := start + length - 1
+		if end < start {
+			return nil, fmt.Errorf("seed range from %d runs past %d", start, uint(math.MaxUint))
+		}
+		ranges = append(ranges, Interval{start, end})
+	}
+	return ranges, nil
+}
+
 func part2(input string) uint {
-	seeds, maps := parseInput(input)
-	seedPairs := collections.Chunks(seeds, 2)
-	minLocation := seeds[0]
+	almanac := cast.Must(parseInput(input))
+	ranges := cast.Must(seedRanges(almanac.seeds))
+	// not seeds[0], that's a seed number rather than a location
+	minLocation := uint(math.MaxUint)
 
-	for _, seedPair := range seedPairs {
-		start := seedPair[0]
-		end := start + seedPair[1] - 1
-		intervals := []Interval{{start, end}}
+	for _, seedRange := range ranges {
+		intervals := []Interval{seedRange}
 
-		for _, convMap := range maps {
+		for _, convMap := range almanac.maps {
 			intervals = convMap.splitIntervals(intervals)
 			intervals = convMap.mapIntervals(intervals)
 		}
@@ -188,18 +194,21 @@ func part2(input string) uint {
 	return minLocation
 }
 
-func buildMapRange(input string) *ConversionMap {
+func buildMapRange(section inputs.Section) (*ConversionMap, error) {
 	conversionMap := ConversionMap{}
 
-	_, data, _ := strings.Cut(input, " map:\n")
-
-	for _, line := range strings.Split(data, "\n") {
-		nums := strings.Fields(line)
-		mapRange := newMapRange(
-			uint(cast.ToInt(nums[0])),
-			uint(cast.ToInt(nums[1])),
-			uint(cast.ToInt(nums[2])),
-		)
+	for _, line := range strings.Split(section.Body, "\n") {
+		nums, err := cast.TryExtract[uint](line, cast.ExtractUnsigned)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", section.Header, err)
+		}
+		if len(nums) != 3 {
+			return nil, fmt.Errorf("%s: want destination, source and length in %q", section.Header, line)
+		}
+		if nums[2] == 0 {
+			return nil, fmt.Errorf("%s: empty range in %q", section.Header, line)
+		}
+		mapRange := newMapRange(nums[0], nums[1], nums[2])
 		conversionMap.ranges = append(conversionMap.ranges, mapRange)
 	}
 
@@ -207,21 +216,43 @@ func buildMapRange(input string) *ConversionMap {
 		return conversionMap.ranges[i].destination.start < conversionMap.ranges[j].destination.start
 	})
 
-	return &conversionMap
+	return &conversionMap, nil
 }
 
-func parseInput(input string) (seeds []uint, conversionMaps []*ConversionMap) {
-	seperator := "\n\n"
+// Almanac is the seeds to plant and the maps from seed numbers through to
+// locations, in order
+type Almanac struct {
+	seeds []uint
+	maps  []*ConversionMap
+}
 
-	seedLine, dataLine, _ := strings.Cut(input, seperator)
-	seedStrings := strings.Fields(seedLine)[1:]
+func parseInput(input string) (*Almanac, error) {
+	sections := inputs.Sections(input)
+	if len(sections) == 0 {
+		return nil, errors.New("no seeds")
+	}
 
-	for _, seedStr := range seedStrings {
-		seeds = append(seeds, uint(cast.ToInt(seedStr)))
+	if sections[0].Header != "seeds" {
+		return nil, fmt.Errorf("want seeds first, got %q", sections[0].Header)
+	}
+	seeds, err := cast.TryExtract[uint](sections[0].Body, cast.ExtractUnsigned)
+	if err != nil {
+		return nil, fmt.Errorf("seeds: %w", err)
+	}
+	if len(seeds) == 0 {
+		return nil, fmt.Errorf("no seeds in %q", sections[0].Body)
 	}
-	for _, mapStr := range strings.Split(dataLine, seperator) {
-		conversionMaps = append(conversionMaps, buildMapRange(mapStr))
+	almanac := &Almanac{seeds: seeds}
+	for _, section := range sections[1:] {
+		if !strings.HasSuffix(section.Header, " map") {
+			return nil, fmt.Errorf("want a map, got %q", section.Header)
+		}
+		conversionMap, err := buildMapRange(section)
+		if err != nil {
+			return nil, err
+		}
+		almanac.maps = append(almanac.maps, conversionMap)
 	}
 
-	return seeds, conversionMaps
+	return almanac, nil
 }
@@ -1,7 +1,14 @@
 package main
 
 import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example = `seeds: 79 14 55 13
@@ -89,3 +96,103 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, parseInput)
+	})
+}
+
+func Test_seedRanges_bad(t *testing.T) {
+	tests := []struct {
+		name  string
+		seeds []uint
+	}{
+		{"odd seed count", []uint{79, 14, 55}},
+		{"empty range", []uint{79, 0}},
+		{"range past the top", []uint{math.MaxUint, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := seedRanges(tt.seeds); err == nil {
+				t.Errorf("seedRanges() = %v, want an error", got)
+			}
+		})
+	}
+}
+
+// randomAlmanac makes an almanac with size seed ranges and seven maps of about
+// size ranges, whose source ranges are disjoint like the real ones
+func randomAlmanac(r *rand.Rand, size int) string {
+	var sb strings.Builder
+	sb.WriteString("seeds:")
+	for i := 0; i < size; i++ {
+		fmt.Fprintf(&sb, " %d %d", r.Intn(50*size), 1+r.Intn(10))
+	}
+	for m := 0; m < 7; m++ {
+		fmt.Fprintf(&sb, "\n\n%c-to-%c map:", 'a'+m, 'b'+m)
+		source := r.Intn(10)
+		for i := 0; i < size/2+1+r.Intn(size/2+1); i++ {
+			length := 1 + r.Intn(15)
+			fmt.Fprintf(&sb, "\n%d %d %d", r.Intn(60*size), source, length)
+			source += length + r.Intn(5)
+		}
+	}
+	return sb.String()
+}
+
+// bruteForcePart2 maps every seed in the ranges one at a time, panicking on
+// almanacs the puzzle wouldn't give
+func bruteForcePart2(input string) uint {
+	almanac := cast.Must(parseInput(input))
+	seeds, maps := almanac.seeds, almanac.maps
+	if len(seeds)%2 != 0 {
+		panic("odd number of seed numbers")
+	}
+	for _, m := range maps {
+		for i, a := range m.ranges {
+			for _, b := range m.ranges[i+1:] {
+				if a.source.start <= b.source.end && b.source.start <= a.source.end {
+					panic("overlapping source ranges")
+				}
+			}
+		}
+	}
+
+	lowest := uint(math.MaxUint)
+	for i := 0; i < len(seeds); i += 2 {
+		if seeds[i+1] == 0 {
+			panic("empty seed range")
+		}
+		for seed := seeds[i]; seed < seeds[i]+seeds[i+1]; seed++ {
+			location := seed
+			for _, m := range maps {
+				location = m.mapValue(location)
+			}
+			lowest = min(lowest, location)
+		}
+	}
+	return lowest
+}
+
+func Test_oracle(t *testing.T) {
+	small := func(r *rand.Rand) string { return randomAlmanac(r, 1+r.Intn(4)) }
+	harness.Oracle(t, small, part2, bruteForcePart2, harness.ShrinkBlocks, harness.ShrinkLines, harness.ShrinkNumbers)
+}
+
+// both parts check every seed against every range of a map, so they're
+// quadratic when both grow
+func Benchmark_scaling(b *testing.B) {
+	b.Run("part1", func(b *testing.B) {
+		harness.Scaling(b, randomAlmanac, part1, harness.Quadratic)
+	})
+	b.Run("part2", func(b *testing.B) {
+		harness.Scaling(b, randomAlmanac, part2, harness.Quadratic)
+	})
+}
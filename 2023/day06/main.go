@@ -1,15 +1,15 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
+	"embed"
 	"fmt"
-	"log"
-	"strconv"
 	"strings"
-	"time"
 
-	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/maths"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
 type raceRecord struct {
@@ -19,139 +19,77 @@ type raceRecord struct {
 
 const IncrementPerMilisecond = 1
 
-func isBetterDistance(holdTime int64, race *raceRecord) bool {
-	remainingTime := race.time - holdTime
-	speed := holdTime * IncrementPerMilisecond
-	return remainingTime*speed > race.distance
-}
-
-func findMin(race *raceRecord) (min int64) {
-	min = 0
-	max := race.time
-
-	for min < max {
-		mid := min + (max-min)/2
-		if isBetterDistance(mid, race) {
-			max = mid
-		} else {
-			min = mid + 1
-		}
+// winningHoldTimes returns how many hold times beat the record. Holding for h
+// travels h*(time-h), so the winners are where -h^2 + time*h - distance > 0.
+func winningHoldTimes(race *raceRecord) int64 {
+	minHoldTime, maxHoldTime, ok := maths.QuadraticPositiveRange(-IncrementPerMilisecond, race.time*IncrementPerMilisecond, -race.distance)
+	if !ok {
+		return 0
 	}
-
-	return min
+	return maxHoldTime - minHoldTime + 1
 }
 
-func findMax(race *raceRecord) (max int64) {
-	var min int64 = 0
-	max = race.time
-
-	for min < max {
-		mid := min + (max-min)/2
-		if isBetterDistance(mid, race) {
-			min = mid + 1
-		} else {
-			max = mid
-		}
-	}
-
-	return max - 1
-}
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
 
-//go:embed input.txt
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-
-	fmt.Println("Time:", time.Since(start))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) (total int64) {
-	races := parseInput(input)
+	races := cast.Must(parseInput(input))
 	total = 1
 
 	for _, race := range races {
-		minHoldTime := findMin(&race)
-		maxHoldTime := findMax(&race)
-		total *= maxHoldTime - minHoldTime + 1
+		total *= winningHoldTimes(&race)
 	}
 
 	return total
 }
 
 func part2(input string) int64 {
-	race := parsePart2(input)
-	log.Println(race)
+	race := cast.Must(parsePart2(input))
 
-	minHoldTime := findMin(&race)
-	maxHoldTime := findMax(&race)
-
-	return maxHoldTime - minHoldTime + 1
+	return winningHoldTimes(&race)
 }
 
-func parseInput(input string) (races []raceRecord) {
-	lines := strings.Split(input, "\n")
-	for lineIdx, line := range lines {
-		_, dataStr, ok := strings.Cut(line, ":")
-		if !ok {
-			panic("invalid input")
-		}
-
-		nums := strings.Fields(dataStr)
-
-		if len(races) == 0 {
-			races = make([]raceRecord, len(nums))
-		}
-
-		for i, numStr := range nums {
-			if lineIdx == 0 {
-				races[i].time, _ = strconv.ParseInt(numStr, 10, 64)
-			} else {
-				races[i].distance, _ = strconv.ParseInt(numStr, 10, 64)
-			}
-		}
+func parseInput(input string) (races []raceRecord, err error) {
+	timeLine, distanceLine, _ := strings.Cut(input, "\n")
+	times, err := cast.TryExtract[int64](timeLine, cast.ExtractUnsigned)
+	if err != nil {
+		return nil, fmt.Errorf("times %q: %w", timeLine, err)
+	}
+	distances, err := cast.TryExtract[int64](distanceLine, cast.ExtractUnsigned)
+	if err != nil {
+		return nil, fmt.Errorf("distances %q: %w", distanceLine, err)
+	}
+	if len(times) != len(distances) {
+		return nil, fmt.Errorf("%d times but %d distances", len(times), len(distances))
 	}
-	return races
-}
-
-func parsePart2(input string) (race raceRecord) {
-	lines := strings.Split(input, "\n")
-
-	for lineIdx, line := range lines {
-		_, dataStr, ok := strings.Cut(line, ":")
-		if !ok {
-			panic("invalid input")
-		}
 
-		num := strings.Join(strings.Fields(dataStr), "")
+	for i := range times {
+		races = append(races, raceRecord{times[i], distances[i]})
+	}
+	return races, nil
+}
 
-		if lineIdx == 0 {
-			race.time, _ = strconv.ParseInt(num, 10, 64)
-		} else {
-			race.distance, _ = strconv.ParseInt(num, 10, 64)
-		}
+func parsePart2(input string) (race raceRecord, err error) {
+	// kerning: the digits on each line are one number
+	nums, err := cast.TryExtract[int64](strings.ReplaceAll(input, " ", ""), cast.ExtractUnsigned)
+	if err != nil {
+		return race, err
+	}
+	if len(nums) != 2 {
+		return race, fmt.Errorf("want a time and a distance, got %d numbers", len(nums))
 	}
-	return race
+	return raceRecord{nums[0], nums[1]}, nil
 }
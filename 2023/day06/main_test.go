@@ -1,7 +1,13 @@
 package main
 
 import (
+	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example = `Time:      7  15   30
@@ -58,3 +64,115 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// binary search solution the closed form replaced, kept to check against
+func isBetterDistance(holdTime int64, race *raceRecord) bool {
+	remainingTime := race.time - holdTime
+	speed := holdTime * IncrementPerMilisecond
+	return remainingTime*speed > race.distance
+}
+
+func findMin(race *raceRecord) (min int64) {
+	min = 0
+	max := race.time
+
+	for min < max {
+		mid := min + (max-min)/2
+		if isBetterDistance(mid, race) {
+			max = mid
+		} else {
+			min = mid + 1
+		}
+	}
+
+	return min
+}
+
+func findMax(race *raceRecord) (max int64) {
+	var min int64 = 0
+	max = race.time
+
+	for min < max {
+		mid := min + (max-min)/2
+		if isBetterDistance(mid, race) {
+			min = mid + 1
+		} else {
+			max = mid
+		}
+	}
+
+	return max - 1
+}
+
+func Test_winningHoldTimes(t *testing.T) {
+	races := append(cast.Must(parseInput(example)), cast.Must(parsePart2(example)),
+		raceRecord{time: 8, distance: 15},
+		raceRecord{time: 49787980, distance: 298118510661181},
+		raceRecord{time: 4000000000, distance: 3999999999999999999},
+	)
+	for _, race := range races {
+		race := race
+		t.Run(fmt.Sprintf("%d_%d", race.time, race.distance), func(t *testing.T) {
+			want := findMax(&race) - findMin(&race) + 1
+			if got := winningHoldTimes(&race); got != want {
+				t.Errorf("winningHoldTimes() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, parseInput)
+		harness.CheckParse(t, input, parsePart2)
+	})
+}
+
+// randomRaces makes one or two races short enough to brute force part 2, with
+// records that can be beaten or not
+func randomRaces(r *rand.Rand) string {
+	var times, distances strings.Builder
+	for i := 0; i < 1+r.Intn(2); i++ {
+		time := r.Intn(60)
+		fmt.Fprintf(&times, " %d", time)
+		fmt.Fprintf(&distances, " %d", r.Intn(time*time/4+5))
+	}
+	return "Time:" + times.String() + "\nDistance:" + distances.String()
+}
+
+// countWins tries every hold time
+func countWins(race raceRecord) (wins int64) {
+	for hold := int64(0); hold <= race.time; hold++ {
+		if hold*(race.time-hold) > race.distance {
+			wins++
+		}
+	}
+	return wins
+}
+
+func bruteForcePart1(input string) int64 {
+	total := int64(1)
+	for _, race := range cast.Must(parseInput(input)) {
+		total *= countWins(race)
+	}
+	return total
+}
+
+func bruteForcePart2(input string) int64 {
+	return countWins(cast.Must(parsePart2(input)))
+}
+
+func Test_oracle(t *testing.T) {
+	t.Run("part1", func(t *testing.T) {
+		harness.Oracle(t, randomRaces, part1, bruteForcePart1, harness.ShrinkNumbers)
+	})
+	t.Run("part2", func(t *testing.T) {
+		harness.Oracle(t, randomRaces, part2, bruteForcePart2, harness.ShrinkNumbers)
+	})
+}
@@ -1,15 +1,15 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
+	"embed"
 	"fmt"
 	"slices"
-	"strings"
-	"time"
 
 	"github.com/Kris-Pelteshki/aoc_2023/cast"
-	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/scan"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
 type card = rune
@@ -174,39 +174,24 @@ func getTotal(hands []*Hand, priorityMap *labelsPriorityMap) (total int) {
 	return total
 }
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-
-	fmt.Println("Time:", time.Since(start))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) int {
-	hands := parseInput(input)
+	hands := cast.Must(parseInput(input))
 
 	for i := range hands {
 		hands[i].setHandType()
@@ -216,7 +201,7 @@ func part1(input string) int {
 }
 
 func part2(input string) (total int) {
-	hands := parseInput(input)
+	hands := cast.Must(parseInput(input))
 
 	for i := range hands {
 		hands[i].setHandTypeWithWildCard('J')
@@ -225,13 +210,29 @@ func part2(input string) (total int) {
 	return getTotal(hands, &LabelsPriorityPart2)
 }
 
-func parseInput(input string) (hands []*Hand) {
-	for _, line := range util.SplitLines(input) {
-		fields := strings.Fields(line)
-		hands = append(hands, &Hand{
-			cards: []rune(fields[0]),
-			bid:   cast.ToInt(fields[1]),
-		})
+// handLine is a line of the input, before its cards are checked. Cards can
+// be digits, like 32T3K 765, so cast.Extract would mix them up with the bid.
+type handLine struct {
+	Cards string
+	Bid   int
+}
+
+func parseInput(input string) (hands []*Hand, err error) {
+	var lines []handLine
+	if err := scan.Unmarshal(input, &lines); err != nil {
+		return nil, err
+	}
+	for i, line := range lines {
+		cards := []rune(line.Cards)
+		if len(cards) != 5 {
+			return nil, fmt.Errorf("line %d: want 5 cards, got %q", i+1, line.Cards)
+		}
+		for _, c := range cards {
+			if _, ok := LabelsPriority[c]; !ok {
+				return nil, fmt.Errorf("line %d: unknown card %q", i+1, c)
+			}
+		}
+		hands = append(hands, &Hand{cards: cards, bid: line.Bid})
 	}
-	return hands
+	return hands, nil
 }
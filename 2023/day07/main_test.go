@@ -1,7 +1,12 @@
 package main
 
 import (
+	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example = `32T3K 765
@@ -61,3 +66,40 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, parseInput)
+	})
+}
+
+// randomHands makes size hands with bids up to 1000
+func randomHands(r *rand.Rand, size int) string {
+	const labels = "23456789TJQKA"
+	var sb strings.Builder
+	for i := 0; i < size; i++ {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		for j := 0; j < 5; j++ {
+			sb.WriteByte(labels[r.Intn(len(labels))])
+		}
+		fmt.Fprintf(&sb, " %d", 1+r.Intn(1000))
+	}
+	return sb.String()
+}
+
+func Benchmark_scaling(b *testing.B) {
+	b.Run("part1", func(b *testing.B) {
+		harness.Scaling(b, randomHands, part1, harness.Linear)
+	})
+	b.Run("part2", func(b *testing.B) {
+		harness.Scaling(b, randomHands, part2, harness.Linear)
+	})
+}
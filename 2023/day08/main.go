@@ -1,15 +1,18 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
+	"embed"
+	"errors"
 	"fmt"
-	"log"
-	"strings"
-	"time"
+	"math/big"
+	"slices"
 
-	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
 	"github.com/Kris-Pelteshki/aoc_2023/util/maths"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/scan"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
 type DirectionTuple = [2]string
@@ -20,102 +23,195 @@ var Directions = map[string]int{
 	"R": 1,
 }
 
+type node struct {
+	Name  string
+	Left  string
+	Right string
+}
+
+var nodePattern = scan.MustCompile("{name} = ({left}, {right})")
+
 type PathFinder struct {
 	lookup       LocationLookup
 	instructions string
 }
 
-func (pf *PathFinder) getStepCountBetween(startLoc, endLoc string) (steps int) {
+// getStepCountBetween follows the instructions from startLoc to endLoc. It's
+// an error if it would go round forever, once it's taken more steps than
+// there are nodes at every point in the instructions.
+func (pf *PathFinder) getStepCountBetween(startLoc, endLoc string) (steps int, err error) {
+	if _, ok := pf.lookup[startLoc]; !ok {
+		return 0, fmt.Errorf("no node %s", startLoc)
+	}
 	getNextDir := newInstructionIterator(pf.instructions)
 	current := startLoc
 
 	for current != endLoc {
+		if steps > len(pf.lookup)*len(pf.instructions) {
+			return 0, fmt.Errorf("%s never leads to %s", startLoc, endLoc)
+		}
 		steps++
-		edges, hasLoc := pf.lookup[current]
-		dir := getNextDir()
+		current = pf.lookup[current][Directions[getNextDir()]]
+	}
 
-		if !hasLoc {
-			log.Fatalf("unknown location: %v", current)
-		}
+	return steps, nil
+}
 
-		current = edges[Directions[dir]]
-	}
+// ghostPath is the steps a ghost is on a node ending in Z. Once it's been
+// round the network long enough it goes round the same loop of nodes and
+// instructions forever, from step loopStart on and loopLength steps long.
+type ghostPath struct {
+	loopStart, loopLength int
+	// zSteps are the steps before the end of the first time round the loop
+	// that it's on a Z node
+	zSteps []int
+}
 
-	return steps
+// onZ reports whether the ghost is on a Z node after steps steps
+func (p ghostPath) onZ(steps int) bool {
+	if steps >= p.loopStart {
+		steps = p.loopStart + (steps-p.loopStart)%p.loopLength
+	}
+	return slices.Contains(p.zSteps, steps)
 }
 
-func (pf *PathFinder) getStepCountBetweenFunc(startLoc string, foundEnd func(loc *string) bool) (steps int, endLocation string) {
-	getNextDir := newInstructionIterator(pf.instructions)
+// tracePath follows a ghost from startLoc until it's on a node it's been on
+// at the same point in the instructions, which it'll loop back to forever
+func (pf *PathFinder) tracePath(startLoc string) ghostPath {
+	type state struct {
+		loc         string
+		instruction int
+	}
+	seen := map[state]int{}
+	var path ghostPath
 	current := startLoc
 
-	for !foundEnd(&current) {
-		steps++
-		edges, hasLoc := pf.lookup[current]
-		dir := getNextDir()
+	for steps := 0; ; steps++ {
+		s := state{current, steps % len(pf.instructions)}
+		if first, ok := seen[s]; ok {
+			path.loopStart, path.loopLength = first, steps-first
+			return path
+		}
+		seen[s] = steps
+		if endsWithZ(&current) {
+			path.zSteps = append(path.zSteps, steps)
+		}
+		dir := pf.instructions[s.instruction : s.instruction+1]
+		current = pf.lookup[current][Directions[dir]]
+	}
+}
+
+// congruence is the steps that are rem mod mod
+type congruence struct {
+	rem, mod int
+}
+
+// combine is the steps in both a and b, by the Chinese remainder theorem for
+// moduli that needn't be coprime, or false if there are none
+func combine(a, b congruence) (congruence, bool) {
+	g := maths.GCD(a.mod, b.mod)
+	if (b.rem-a.rem)%g != 0 {
+		return congruence{}, false
+	}
+	// a.rem + a.mod*k is b.rem mod b.mod for k = (b.rem-a.rem)/g times the
+	// inverse of a.mod/g, mod b.mod/g
+	m := b.mod / g
+	k := big.NewInt(0)
+	if m > 1 {
+		k.ModInverse(big.NewInt(int64(a.mod/g)), big.NewInt(int64(m)))
+		k.Mul(k, big.NewInt(int64((b.rem-a.rem)/g)))
+		k.Mod(k, big.NewInt(int64(m)))
+	}
+	mod := a.mod * m
+	return congruence{rem: (a.rem + a.mod*int(k.Int64())) % mod, mod: mod}, true
+}
 
-		if !hasLoc {
-			log.Fatalf("unknown location: %v", current)
+// stepsUntilAllOnZ is the first step every ghost is on a Z node at once. The
+// ghosts' first Z nodes needn't come round again in step, so their loops
+// are lined up rather than just the steps to their first Z.
+func stepsUntilAllOnZ(paths []ghostPath) (int, error) {
+	// before every ghost is in its loop, check each step
+	looping := 0
+	for _, p := range paths {
+		looping = max(looping, p.loopStart)
+	}
+	for steps := 0; steps < looping; steps++ {
+		if allOnZ(paths, steps) {
+			return steps, nil
 		}
+	}
+
+	// after, a ghost is on a Z node at steps congruent to those in its loop
+	candidates := []congruence{{rem: 0, mod: 1}}
+	for _, p := range paths {
+		var next []congruence
+		for _, c := range candidates {
+			for _, z := range p.zSteps {
+				if z < p.loopStart {
+					continue
+				}
+				if both, ok := combine(c, congruence{rem: z % p.loopLength, mod: p.loopLength}); ok {
+					next = append(next, both)
+				}
+			}
+		}
+		candidates = next
+	}
 
-		current = edges[Directions[dir]]
+	best := -1
+	for _, c := range candidates {
+		steps := looping + ((c.rem-looping)%c.mod+c.mod)%c.mod
+		if best == -1 || steps < best {
+			best = steps
+		}
+	}
+	if best == -1 {
+		return 0, errors.New("the ghosts are never all on Z nodes at once")
 	}
+	return best, nil
+}
 
-	return steps, current
+func allOnZ(paths []ghostPath, steps int) bool {
+	for _, p := range paths {
+		if !p.onZ(steps) {
+			return false
+		}
+	}
+	return true
 }
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-
-	fmt.Println("Time:", time.Since(start))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) int {
-	pf := parseInput(input)
-	return pf.getStepCountBetween("AAA", "ZZZ")
+	pf := cast.Must(parseInput(input))
+	return cast.Must(pf.getStepCountBetween("AAA", "ZZZ"))
 }
 
 func part2(input string) int {
-	pf := parseInput(input)
-	locsEndingInA := []string{}
-	distances := []int{}
+	pf := cast.Must(parseInput(input))
+	paths := []ghostPath{}
 
 	for loc := range pf.lookup {
 		if endsWithA(&loc) {
-			locsEndingInA = append(locsEndingInA, loc)
+			paths = append(paths, pf.tracePath(loc))
 		}
 	}
 
-	for _, locA := range locsEndingInA {
-		distance, _ := pf.getStepCountBetweenFunc(locA, endsWithZ)
-		distances = append(distances, distance)
-	}
-
-	return maths.LCM(distances...)
+	return cast.Must(stepsUntilAllOnZ(paths))
 }
 
 func endsWithA(loc *string) bool {
@@ -140,16 +236,40 @@ func newInstructionIterator(instructions string) func() string {
 	}
 }
 
-func parseInput(input string) *PathFinder {
+func parseInput(input string) (*PathFinder, error) {
 	lookup := make(LocationLookup)
-	instructions, locationLines, _ := strings.Cut(input, "\n\n")
+	blocks := inputs.Blocks(input)
+	if len(blocks) != 2 {
+		return nil, fmt.Errorf("want instructions and nodes, got %d blocks", len(blocks))
+	}
+	instructions, locationLines := blocks[0], blocks[1]
+	if instructions == "" {
+		return nil, errors.New("no instructions")
+	}
+	for _, dir := range instructions {
+		if _, ok := Directions[string(dir)]; !ok {
+			return nil, fmt.Errorf("unknown direction %q", dir)
+		}
+	}
 
-	for _, line := range strings.Split(locationLines, "\n") {
-		split := strings.Split(line, " = ")
-		dirsStr := strings.Trim(split[1], "()")
-		dirs := strings.Split(dirsStr, ", ")
-		lookup[split[0]] = [2]string{dirs[0], dirs[1]}
+	nodes, err := scan.ScanLines[node](nodePattern, locationLines)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.Name == "" {
+			return nil, errors.New("node without a name")
+		}
+		lookup[n.Name] = DirectionTuple{n.Left, n.Right}
+	}
+	// so walking the network never steps off it
+	for name, edges := range lookup {
+		for _, to := range edges {
+			if _, ok := lookup[to]; !ok {
+				return nil, fmt.Errorf("%s leads to %s, which isn't a node", name, to)
+			}
+		}
 	}
 
-	return &PathFinder{lookup, instructions}
+	return &PathFinder{lookup, instructions}, nil
 }
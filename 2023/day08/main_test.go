@@ -1,7 +1,13 @@
 package main
 
 import (
+	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example = `LLR
@@ -72,3 +78,177 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Add(example2)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, parseInput)
+	})
+}
+
+func Test_parseInput_unknownNode(t *testing.T) {
+	input := "LR\n\nAAA = (BBB, ZZZ)\nZZZ = (ZZZ, ZZZ)"
+	if pf, err := parseInput(input); err == nil {
+		t.Errorf("parseInput() = %v, want an error", pf)
+	}
+}
+
+func Test_getStepCountBetween_unreachable(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"no start", "L\n\nBBB = (ZZZ, ZZZ)\nZZZ = (ZZZ, ZZZ)"},
+		{"end off the path", "L\n\nAAA = (BBB, ZZZ)\nBBB = (AAA, ZZZ)\nZZZ = (ZZZ, ZZZ)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pf, err := parseInput(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if steps, err := pf.getStepCountBetween("AAA", "ZZZ"); err == nil {
+				t.Errorf("getStepCountBetween() = %v, want an error", steps)
+			}
+		})
+	}
+}
+
+// randomNetwork makes about size nodes: AAA and three ghosts' starts, each
+// leading through its own ladder of node pairs to its end. Either direction
+// from a rung goes to the next rung, and the end loops back to the first, so
+// every start reaches its end in the same number of steps each time around,
+// whatever the instructions.
+func randomNetwork(r *rand.Rand, size int) string {
+	var sb strings.Builder
+	for i := 0; i < 2+r.Intn(size); i++ {
+		sb.WriteByte("LR"[r.Intn(2)])
+	}
+	sb.WriteString("\n")
+
+	// three letter names that end in neither A nor Z
+	const lasts = "BCDEFGHIJKLMNOPQRSTUVWXY"
+	next := 0
+	name := func() string {
+		n := next
+		next++
+		return fmt.Sprintf("%c%c%c", 'A'+n/len(lasts)/26%26, 'A'+n/len(lasts)%26, lasts[n%len(lasts)])
+	}
+
+	ghosts := [][2]string{{"AAA", "ZZZ"}, {"11A", "11Z"}, {"22A", "22Z"}, {"33A", "33Z"}}
+	for _, ghost := range ghosts {
+		start, end := ghost[0], ghost[1]
+		rungs := max(1, size/8) + r.Intn(max(1, size/8))
+		left, right := name(), name()
+		firstLeft, firstRight := left, right
+		fmt.Fprintf(&sb, "\n%s = (%s, %s)", start, left, right)
+		for i := 1; i < rungs; i++ {
+			nextLeft, nextRight := name(), name()
+			fmt.Fprintf(&sb, "\n%s = (%s, %s)", left, nextLeft, nextRight)
+			fmt.Fprintf(&sb, "\n%s = (%s, %s)", right, nextLeft, nextRight)
+			left, right = nextLeft, nextRight
+		}
+		fmt.Fprintf(&sb, "\n%s = (%s, %s)", left, end, end)
+		fmt.Fprintf(&sb, "\n%s = (%s, %s)", right, end, end)
+		fmt.Fprintf(&sb, "\n%s = (%s, %s)", end, firstLeft, firstRight)
+	}
+	return sb.String()
+}
+
+// loopingNetwork makes AAA and three ghosts' starts, each leading down a
+// tail of nodes into a loop with its end part way round. The loops are
+// different prime lengths, so the ghosts do all meet on their ends, but
+// the steps to each end the first time aren't a whole number of loops and
+// multiplying them out misses.
+func loopingNetwork(r *rand.Rand, size int) string {
+	var sb strings.Builder
+	for i := 0; i < 2+r.Intn(size); i++ {
+		sb.WriteByte("LR"[r.Intn(2)])
+	}
+	sb.WriteString("\n")
+
+	const lasts = "BCDEFGHIJKLMNOPQRSTUVWXY"
+	next := 0
+	name := func() string {
+		n := next
+		next++
+		return fmt.Sprintf("%c%c%c", 'A'+n/len(lasts)/26%26, 'A'+n/len(lasts)%26, lasts[n%len(lasts)])
+	}
+
+	primes := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61}
+	lengths := r.Perm(min(len(primes), 4+size/8))
+	ghosts := [][2]string{{"AAA", "ZZZ"}, {"11A", "11Z"}, {"22A", "22Z"}, {"33A", "33Z"}}
+	for i, ghost := range ghosts {
+		start, end := ghost[0], ghost[1]
+		var path []string
+		for tail := 1 + r.Intn(1+size/4); tail > 0; tail-- {
+			path = append(path, name())
+		}
+		loopStart := len(path)
+		loopLength := primes[lengths[i]]
+		endAt := r.Intn(loopLength)
+		for j := 0; j < loopLength; j++ {
+			if j == endAt {
+				path = append(path, end)
+			} else {
+				path = append(path, name())
+			}
+		}
+
+		fmt.Fprintf(&sb, "\n%s = (%s, %s)", start, path[0], path[0])
+		for j, node := range path {
+			to := loopStart
+			if j+1 < len(path) {
+				to = j + 1
+			}
+			fmt.Fprintf(&sb, "\n%s = (%s, %s)", node, path[to], path[to])
+		}
+	}
+	return sb.String()
+}
+
+// bruteForcePart2 moves every ghost a step at a time until they're all on a
+// Z node
+func bruteForcePart2(input string) int {
+	pf := cast.Must(parseInput(input))
+	var ghosts []string
+	for loc := range pf.lookup {
+		if endsWithA(&loc) {
+			ghosts = append(ghosts, loc)
+		}
+	}
+
+	for steps := 0; ; steps++ {
+		done := true
+		for _, ghost := range ghosts {
+			done = done && endsWithZ(&ghost)
+		}
+		if done {
+			return steps
+		}
+		dir := Directions[string(pf.instructions[steps%len(pf.instructions)])]
+		for i, ghost := range ghosts {
+			ghosts[i] = pf.lookup[ghost][dir]
+		}
+	}
+}
+
+func Test_oracle(t *testing.T) {
+	small := func(r *rand.Rand) string { return loopingNetwork(r, 1+r.Intn(16)) }
+	harness.Oracle(t, small, part2, bruteForcePart2)
+}
+
+func Benchmark_scaling(b *testing.B) {
+	b.Run("part1", func(b *testing.B) {
+		harness.Scaling(b, randomNetwork, part1, harness.Linear)
+	})
+	b.Run("part2", func(b *testing.B) {
+		harness.Scaling(b, randomNetwork, part2, harness.Linear)
+	})
+}
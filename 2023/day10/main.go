@@ -1,13 +1,17 @@
 package main
 
 import (
-	_ "embed"
+	"embed"
 	"flag"
 	"fmt"
 	"strings"
-	"time"
 
-	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+	"github.com/Kris-Pelteshki/aoc_2023/util/geometry"
+	gridutil "github.com/Kris-Pelteshki/aoc_2023/util/grid"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 	"github.com/emirpasic/gods/queues/arrayqueue"
 )
 
@@ -16,6 +20,12 @@ import (
 
 type Grid [][]string
 
+// Maze is the grid of pipes and the tile the animal starts on
+type Maze struct {
+	grid  Grid
+	start [2]int
+}
+
 const StartSymbol = "S"
 
 var dirs = [4][2]int{
@@ -25,39 +35,35 @@ var dirs = [4][2]int{
 	{-1, 0},
 }
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
+	opts := runner.Flags()
+	var render bool
+	flag.BoolVar(&render, "render", false, "print the maze with the loop highlighted")
 	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
 
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
+	if render {
+		renderLoop(input)
+	}
+	if err := runner.Run(opts, input, runner.Quick(part1), runner.Quick(part2)); err != nil {
+		runner.Exit(err)
 	}
-
-	fmt.Println("Time:", time.Since(start))
 }
 
 func part1(input string) int {
-	grid, startPos := inputToGrid(input)
+	maze := cast.Must(inputToGrid(input))
+	grid, startPos := maze.grid, maze.start
 	visited := make(map[[2]int]bool)
 
 	queue := arrayqueue.New()
@@ -87,17 +93,77 @@ func part1(input string) int {
 }
 
 func part2(input string) int {
-	return 0
+	maze := cast.Must(inputToGrid(input))
+	return cast.Must(traceLoop(&maze.grid, maze.start)).InteriorPoints()
 }
 
-func getNeighbors(grid *Grid, x int, y int) (neighbors [][2]int) {
-	gridLen := len(*grid)
+// traceLoop walks the pipe loop from the start position and returns every
+// tile on it in order, which makes it a polygon of unit length edges. It's
+// an error if the start doesn't join two pipes, or the pipes from it don't
+// lead back to it.
+func traceLoop(grid *Grid, startPos [2]int) (loop geometry.Polygon, err error) {
+	ends := getConnectedNeighbors(grid, startPos[0], startPos[1])
+	if len(ends) != 2 {
+		return nil, fmt.Errorf("want 2 pipes joining %s, got %d", StartSymbol, len(ends))
+	}
+	prev, current := startPos, ends[0]
+	loop = append(loop, geometry.Point{X: startPos[0], Y: startPos[1]})
+
+	// every step is checked to join both ways, so the walk can't go round a
+	// loop that S isn't on
+	for current != startPos {
+		loop = append(loop, geometry.Point{X: current[0], Y: current[1]})
 
+		next, ok := nextPipe(grid, prev, current)
+		if !ok {
+			return nil, fmt.Errorf("the loop is broken at row %d column %d", current[1]+1, current[0]+1)
+		}
+		prev, current = current, next
+	}
+	return loop, nil
+}
+
+// nextPipe is where the pipe at current leads, coming from prev, if it
+// joins both prev and a pipe that joins it back
+func nextPipe(grid *Grid, prev, current [2]int) (next [2]int, ok bool) {
+	ends := getAdjacentPipes(grid, current[0], current[1])
+	switch prev {
+	case ends[0]:
+		next = ends[1]
+	case ends[1]:
+		next = ends[0]
+	default:
+		return next, false
+	}
+
+	x, y := next[0], next[1]
+	if y < 0 || y >= len(*grid) || x < 0 || x >= len((*grid)[y]) {
+		return next, false
+	}
+	if (*grid)[y][x] == StartSymbol {
+		return next, true
+	}
+	back := getAdjacentPipes(grid, x, y)
+	return next, back[0] == current || back[1] == current
+}
+
+func renderLoop(input string) {
+	maze := cast.Must(inputToGrid(input))
+	loop := cast.Must(traceLoop(&maze.grid, maze.start))
+
+	renderer := gridutil.Renderer{
+		BoxDrawing: true,
+		Layers:     []gridutil.Layer{&gridutil.PathLayer{Path: loop, Color: gridutil.Green}},
+	}
+	renderer.Print(gridutil.Parse(input))
+}
+
+func getNeighbors(grid *Grid, x int, y int) (neighbors [][2]int) {
 	for _, dir := range dirs {
 		col := x + dir[0]
 		row := y + dir[1]
 
-		if col < 0 || col >= gridLen || row < 0 || row >= gridLen || (*grid)[row][col] == "." {
+		if row < 0 || row >= len(*grid) || col < 0 || col >= len((*grid)[row]) || (*grid)[row][col] == "." {
 			continue
 		}
 
@@ -158,18 +224,27 @@ func getAdjacentPipes(grid *Grid, x int, y int) (res [2][2]int) {
 	return res
 }
 
-func inputToGrid(input string) (grid Grid, startPos [2]int) {
-	for _, line := range strings.Split(input, "\n") {
-		grid = append(grid, strings.Split(line, ""))
+func inputToGrid(input string) (*Maze, error) {
+	lines := strings.Split(input, "\n")
+	if err := gridutil.CheckRows(lines, "|-LJ7F."+StartSymbol); err != nil {
+		return nil, err
+	}
+	if n := strings.Count(input, StartSymbol); n != 1 {
+		return nil, fmt.Errorf("want one %s, got %d", StartSymbol, n)
+	}
+
+	maze := &Maze{}
+	for _, line := range lines {
+		maze.grid = append(maze.grid, strings.Split(line, ""))
 	}
 
-	for i := range grid {
-		for j := range grid {
-			if grid[i][j] == StartSymbol {
-				startPos = [2]int{j, i}
+	for i := range maze.grid {
+		for j := range maze.grid[i] {
+			if maze.grid[i][j] == StartSymbol {
+				maze.start = [2]int{j, i}
 				break
 			}
 		}
 	}
-	return grid, startPos
+	return maze, nil
 }
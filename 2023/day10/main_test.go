@@ -1,7 +1,12 @@
 package main
 
 import (
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/geometry"
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example = `..F7.
@@ -10,6 +15,38 @@ SJ.L7
 |F--J
 LJ...`
 
+var example2 = `...........
+.S-------7.
+.|F-----7|.
+.||.....||.
+.||.....||.
+.|L-7.F-J|.
+.|..|.|..|.
+.L--J.L--J.
+...........`
+
+var example3 = `.F----7F7F7F7F-7....
+.|F--7||||||||FJ....
+.||.FJ||||||||L7....
+FJL7L7LJLJ||LJ.L-7..
+L--J.L7...LJS7F-7L7.
+....F-J..F7FJ|L7L7L7
+....L7.F7||L7|.L7L7|
+.....|FJLJ|FJ|F7|.LJ
+....FJL-7.||.||||...
+....L---J.LJ.LJLJ...`
+
+var example4 = `FF7FSF7F7F7F7F7F---7
+L|LJ||||||||||||F--J
+FL-7LJLJ||||||LJL-77
+F--JF--7||LJLJ7F7FJ-
+L---JF-JLJ.||-FJLJJ7
+|F|F-JF---7F7-L7L|7|
+|FFJF7L7F-JF7|JL---7
+7-L-JL7||F7|L7F-7F7|
+L.L7LFJ|||||FJL7||LJ
+L7JLJL-JLJLJL--JLJ.L`
+
 func Test_part1(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -45,7 +82,22 @@ func Test_part2(t *testing.T) {
 		{
 			name:  "example",
 			input: example,
-			want:  0,
+			want:  1,
+		},
+		{
+			name:  "example2",
+			input: example2,
+			want:  4,
+		},
+		{
+			name:  "example3",
+			input: example3,
+			want:  8,
+		},
+		{
+			name:  "example4",
+			input: example4,
+			want:  10,
 		},
 		// {
 		// 	name:  "actual",
@@ -61,3 +113,112 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func Test_traceLoop_broken(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"start joins one pipe", "S-7\n..|\n..J"},
+		{"start joins three pipes", ".|.\n-S-\n..."},
+		{"loop runs off the grid", "S7\n|L"},
+		{"loop runs into ground", "S-7\n|.|\nL-."},
+		{"pipe doesn't join back", "S-7\n|.|\nL-L"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maze, err := inputToGrid(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loop, err := traceLoop(&maze.grid, maze.start); err == nil {
+				t.Errorf("traceLoop() = %v, want an error", loop)
+			}
+		})
+	}
+}
+
+// FuzzInputToGrid checks the maze parses or errors cleanly, and that the
+// loop in a parsed one traces or errors cleanly too
+func FuzzInputToGrid(f *testing.F) {
+	f.Add(example)
+	f.Add(example2)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, func(input string) (geometry.Polygon, error) {
+			maze, err := inputToGrid(input)
+			if err != nil {
+				return nil, err
+			}
+			return traceLoop(&maze.grid, maze.start)
+		})
+	})
+}
+
+// pipes joining two directions, as [dx1, dy1, dx2, dy2]
+var pipes = map[[4]int]byte{
+	{0, -1, 0, 1}: '|', {-1, 0, 1, 0}: '-',
+	{0, -1, 1, 0}: 'L', {0, -1, -1, 0}: 'J',
+	{0, 1, -1, 0}: '7', {0, 1, 1, 0}: 'F',
+}
+
+// randomLoop makes a loop of about size x size tiles snaking through every
+// tile of a rectangle, with random pipes that aren't part of it to the right
+// and below. It starts in the top left corner, goes right along the top row,
+// then back and forth through every column but the first, and up the first
+// back to the start.
+func randomLoop(r *rand.Rand, size int) string {
+	width, height := max(2, size), max(2, size+size%2)
+	padding := 1 + size/4
+	var loop [][2]int
+	for x := 0; x < width; x++ {
+		loop = append(loop, [2]int{x, 0})
+	}
+	for y := 1; y < height; y++ {
+		for i := 1; i < width; i++ {
+			x := width - i
+			if y%2 == 0 {
+				x = i
+			}
+			loop = append(loop, [2]int{x, y})
+		}
+	}
+	for y := height - 1; y > 0; y-- {
+		loop = append(loop, [2]int{0, y})
+	}
+
+	rows := strings.Split(harness.RandomGrid(r, width+padding, height+padding, "|-LJ7F.."), "\n")
+	tiles := make([][]byte, len(rows))
+	for y, row := range rows {
+		tiles[y] = []byte(row)
+	}
+	for i, tile := range loop {
+		prev, next := loop[(i+len(loop)-1)%len(loop)], loop[(i+1)%len(loop)]
+		a := [2]int{prev[0] - tile[0], prev[1] - tile[1]}
+		b := [2]int{next[0] - tile[0], next[1] - tile[1]}
+		pipe, ok := pipes[[4]int{a[0], a[1], b[0], b[1]}]
+		if !ok {
+			pipe = pipes[[4]int{b[0], b[1], a[0], a[1]}]
+		}
+		tiles[tile[1]][tile[0]] = pipe
+	}
+	tiles[0][0] = StartSymbol[0]
+
+	for y, row := range tiles {
+		rows[y] = string(row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func Benchmark_scaling(b *testing.B) {
+	b.Run("part1", func(b *testing.B) {
+		harness.Scaling(b, randomLoop, part1, harness.Linear, 20, 40, 80, 160, 320)
+	})
+	b.Run("part2", func(b *testing.B) {
+		harness.Scaling(b, randomLoop, part2, harness.Linear, 20, 40, 80, 160, 320)
+	})
+}
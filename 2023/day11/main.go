@@ -1,15 +1,17 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
-	"fmt"
+	"embed"
 	"slices"
 	"strings"
-	"time"
 
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
 	"github.com/Kris-Pelteshki/aoc_2023/util"
+	gridutil "github.com/Kris-Pelteshki/aoc_2023/util/grid"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
 	"github.com/Kris-Pelteshki/aoc_2023/util/maths"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
 var galaxySymbol = '#'
@@ -24,35 +26,20 @@ func (g *Galaxy) distanceTo(other *Galaxy) (dx, dy int) {
 	return dx, dy
 }
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-
-	fmt.Println("Time:", time.Since(start))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) (total int) {
@@ -64,7 +51,7 @@ func part2(input string) int {
 }
 
 func calcTotalDistancesOfPairs(input string, expandEmptySpaceByFactorOf int) (total int) {
-	universe := getUniverse(input, expandEmptySpaceByFactorOf)
+	universe := cast.Must(getUniverse(input, expandEmptySpaceByFactorOf))
 
 	for i, galaxy := range universe {
 		for _, otherGalaxy := range universe[i+1:] {
@@ -76,8 +63,11 @@ func calcTotalDistancesOfPairs(input string, expandEmptySpaceByFactorOf int) (to
 	return total
 }
 
-func buildGrid(input string, expandEmptySpaceByFactorOf int) (*[]string, []int, []int) {
+func buildGrid(input string, expandEmptySpaceByFactorOf int) (*[]string, []int, []int, error) {
 	grid := util.SplitLines(input)
+	if err := gridutil.CheckRows(grid, "#."); err != nil {
+		return nil, nil, nil, err
+	}
 
 	rowIndexToCoords := make([]int, len(grid))
 	colIndexToCoords := make([]int, len(grid[0]))
@@ -107,12 +97,15 @@ func buildGrid(input string, expandEmptySpaceByFactorOf int) (*[]string, []int,
 	}
 
 	grid = slices.Clip(grid)
-	return &grid, rowIndexToCoords, colIndexToCoords
+	return &grid, rowIndexToCoords, colIndexToCoords, nil
 }
 
 // parses the input into galaxies
-func getUniverse(input string, expandEmptySpaceByFactorOf int) (galaxies []*Galaxy) {
-	grid, rowIndexToCoords, colIndexToCoords := buildGrid(input, expandEmptySpaceByFactorOf)
+func getUniverse(input string, expandEmptySpaceByFactorOf int) (galaxies []*Galaxy, err error) {
+	grid, rowIndexToCoords, colIndexToCoords, err := buildGrid(input, expandEmptySpaceByFactorOf)
+	if err != nil {
+		return nil, err
+	}
 
 	for y, line := range *grid {
 		for x, char := range line {
@@ -122,5 +115,5 @@ func getUniverse(input string, expandEmptySpaceByFactorOf int) (galaxies []*Gala
 		}
 	}
 
-	return galaxies
+	return galaxies, nil
 }
@@ -1,7 +1,12 @@
 package main
 
 import (
+	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example = `...#......
@@ -66,3 +71,95 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzGetUniverse(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, func(input string) ([]*Galaxy, error) {
+			return getUniverse(input, 2)
+		})
+	})
+}
+
+func randomImage(r *rand.Rand) string {
+	return harness.RandomGrid(r, 1+r.Intn(10), 1+r.Intn(10), "......#")
+}
+
+// expandLiterally sums the distances by actually inserting the empty rows and
+// columns, then walking between every pair of galaxies
+func expandLiterally(input string, factor int) (total int) {
+	rows := strings.Split(input, "\n")
+	if strings.Trim(input, ".#\n") != "" {
+		panic("not an image")
+	}
+
+	var tall []string
+	for _, row := range rows {
+		copies := 1
+		if !strings.Contains(row, "#") {
+			copies = factor
+		}
+		for i := 0; i < copies; i++ {
+			tall = append(tall, row)
+		}
+	}
+
+	expanded := make([]strings.Builder, len(tall))
+	for x := range rows[0] {
+		copies := factor
+		for _, row := range rows {
+			if row[x] == '#' {
+				copies = 1
+			}
+		}
+		for y, row := range tall {
+			for i := 0; i < copies; i++ {
+				expanded[y].WriteByte(row[x])
+			}
+		}
+	}
+
+	var galaxies []Galaxy
+	for y := range expanded {
+		for x, c := range expanded[y].String() {
+			if c == '#' {
+				galaxies = append(galaxies, Galaxy{x, y})
+			}
+		}
+	}
+	for i, from := range galaxies {
+		for _, b := range galaxies[i+1:] {
+			a := from
+			for a != b {
+				switch {
+				case a.X < b.X:
+					a.X++
+				case a.X > b.X:
+					a.X--
+				case a.Y < b.Y:
+					a.Y++
+				default:
+					a.Y--
+				}
+				total++
+			}
+		}
+	}
+	return total
+}
+
+func Test_oracle(t *testing.T) {
+	for _, factor := range []int{1, 2, 10} {
+		t.Run(fmt.Sprint("factor", factor), func(t *testing.T) {
+			harness.Oracle(t, randomImage,
+				func(input string) int { return calcTotalDistancesOfPairs(input, factor) },
+				func(input string) int { return expandLiterally(input, factor) },
+				harness.ShrinkLines, harness.ShrinkColumns)
+		})
+	}
+}
@@ -1,68 +1,107 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
-	"fmt"
+	"embed"
 	"strings"
-	"time"
 
 	"github.com/Kris-Pelteshki/aoc_2023/cast"
 	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/scan"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
 type SpringRow struct {
-	row    string
-	groups []int
+	Row    string
+	Groups []int `sep:","`
 }
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-
-	fmt.Println("Time:", time.Since(start))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) (total int) {
-	rows := parseInput(input)
+	rows := cast.Must(parseInput(input))
 
+	for _, row := range rows {
+		total += row.arrangements()
+	}
 	return total
 }
 
-func part2(input string) int {
-	return 0
+func part2(input string) (total int) {
+	rows := cast.Must(parseInput(input))
+
+	for _, row := range rows {
+		total += row.unfold(5).arrangements()
+	}
+	return total
 }
 
-func parseInput(input string) (rows []SpringRow) {
-	for _, line := range util.SplitLines(input) {
-		parts := strings.Split(line, " ")
-		row := parts[0]
-		groups := cast.ToInts(strings.Split(parts[1], ","))
-		rows = append(rows, SpringRow{row, groups})
+// unfold repeats the row times times joined by '?' and the groups times times
+func (s SpringRow) unfold(times int) SpringRow {
+	rows := make([]string, times)
+	groups := make([]int, 0, len(s.Groups)*times)
+	for i := 0; i < times; i++ {
+		rows[i] = s.Row
+		groups = append(groups, s.Groups...)
 	}
-	return rows
+	return SpringRow{strings.Join(rows, "?"), groups}
+}
+
+type arrangementState struct {
+	pos        int
+	groupIndex int
+}
+
+// arrangements counts the ways to fill in the unknown springs so the damaged
+// groups match, placing one group at a time from the left
+func (s SpringRow) arrangements() int {
+	memo := util.NewMemo(func(count func(arrangementState) int, state arrangementState) int {
+		if state.pos >= len(s.Row) {
+			if state.groupIndex == len(s.Groups) {
+				return 1
+			}
+			return 0
+		}
+
+		total := 0
+		spring := s.Row[state.pos]
+
+		if spring == '.' || spring == '?' {
+			total += count(arrangementState{state.pos + 1, state.groupIndex})
+		}
+
+		if (spring == '#' || spring == '?') && state.groupIndex < len(s.Groups) {
+			end := state.pos + s.Groups[state.groupIndex]
+			// group has to fit, can't contain a working spring and has to be
+			// followed by a working one or the end of the row
+			if end <= len(s.Row) &&
+				!strings.Contains(s.Row[state.pos:end], ".") &&
+				(end == len(s.Row) || s.Row[end] != '#') {
+				total += count(arrangementState{end + 1, state.groupIndex + 1})
+			}
+		}
+
+		return total
+	})
+	return memo.Get(arrangementState{0, 0})
+}
+
+func parseInput(input string) (rows []SpringRow, err error) {
+	err = scan.Unmarshal(input, &rows)
+	return rows, err
 }
@@ -1,13 +1,15 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
+	"embed"
 	"fmt"
 	"strings"
-	"time"
 
-	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+	"github.com/Kris-Pelteshki/aoc_2023/util/grid"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
 type Grid struct {
@@ -80,39 +82,24 @@ func getReflectedIndex(inflectionPoint int, index int) int {
 	return 2*inflectionPoint - index + 1
 }
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-
-	fmt.Println("Time:", time.Since(start))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) (total int) {
-	grids := parseInput(input)
+	grids := cast.Must(parseInput(input))
 	rowsAboveMirror := 0
 	colsBeforeMirror := 0
 
@@ -124,7 +111,7 @@ func part1(input string) (total int) {
 }
 
 func part2(input string) (total int) {
-	grids := parseInput(input)
+	grids := cast.Must(parseInput(input))
 	rowsAboveMirror := 0
 	colsBeforeMirror := 0
 
@@ -135,11 +122,15 @@ func part2(input string) (total int) {
 	return (rowsAboveMirror * 100) + colsBeforeMirror
 }
 
-func parseInput(input string) []*Grid {
+func parseInput(input string) ([]*Grid, error) {
 	grids := []*Grid{}
 
-	for _, gridInput := range strings.Split(input, "\n\n") {
-		grids = append(grids, &Grid{rows: strings.Split(gridInput, "\n")})
+	for i, gridInput := range inputs.Blocks(input) {
+		rows := strings.Split(gridInput, "\n")
+		if err := grid.CheckRows(rows, "#."); err != nil {
+			return nil, fmt.Errorf("pattern %d: %w", i+1, err)
+		}
+		grids = append(grids, &Grid{rows: rows})
 	}
-	return grids
+	return grids, nil
 }
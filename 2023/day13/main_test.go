@@ -1,7 +1,12 @@
 package main
 
 import (
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/grid"
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
 )
 
 var example = `#.##..##.
@@ -71,3 +76,114 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, parseInput)
+	})
+}
+
+// transpose swaps rows and columns, so column mirrors can be found as rows
+func transpose(rows []string) []string {
+	cols := make([]string, len(rows[0]))
+	for x := range cols {
+		col := make([]byte, len(rows))
+		for y, row := range rows {
+			col[y] = row[x]
+		}
+		cols[x] = string(col)
+	}
+	return cols
+}
+
+// mirrorRows returns every line between rows that reflects with exactly
+// smudges cells different, as the number of rows above it
+func mirrorRows(rows []string, smudges int) (lines []int) {
+	for above := 1; above < len(rows); above++ {
+		diffs := 0
+		for up, down := above-1, above; up >= 0 && down < len(rows); up, down = up-1, down+1 {
+			for x := range rows[up] {
+				if rows[up][x] != rows[down][x] {
+					diffs++
+				}
+			}
+		}
+		if diffs == smudges {
+			lines = append(lines, above)
+		}
+	}
+	return lines
+}
+
+// summarizeEveryLine checks every possible mirror, panicking on patterns
+// without exactly one like the puzzle promises
+func summarizeEveryLine(input string, smudges int) (total int) {
+	for _, block := range strings.Split(input, "\n\n") {
+		rows := strings.Split(block, "\n")
+		if err := grid.CheckRows(rows, "#."); err != nil {
+			panic(err)
+		}
+		horizontal, vertical := mirrorRows(rows, smudges), mirrorRows(transpose(rows), smudges)
+		if len(horizontal)+len(vertical) != 1 {
+			panic("pattern without exactly one mirror")
+		}
+		for _, above := range horizontal {
+			total += 100 * above
+		}
+		for _, left := range vertical {
+			total += left
+		}
+	}
+	return total
+}
+
+// randomPattern reflects random rows over a random line, with smudges cells
+// flipped, until there's only the one mirror
+func randomPattern(r *rand.Rand, smudges int) string {
+	for {
+		rows := strings.Split(harness.RandomGrid(r, 2+r.Intn(8), 2+r.Intn(8), "#."), "\n")
+		above := 1 + r.Intn(len(rows)-1)
+		for up, down := above-1, above; up >= 0 && down < len(rows); up, down = up-1, down+1 {
+			rows[down] = rows[up]
+		}
+		for i := 0; i < smudges; i++ {
+			y := r.Intn(len(rows))
+			row := []byte(rows[y])
+			x := r.Intn(len(row))
+			row[x] = "#."[r.Intn(2)]
+			rows[y] = string(row)
+		}
+		if r.Intn(2) == 0 {
+			rows = transpose(rows)
+		}
+		if len(mirrorRows(rows, smudges))+len(mirrorRows(transpose(rows), smudges)) == 1 {
+			return strings.Join(rows, "\n")
+		}
+	}
+}
+
+func randomPatterns(r *rand.Rand, smudges int) string {
+	patterns := make([]string, 1+r.Intn(3))
+	for i := range patterns {
+		patterns[i] = randomPattern(r, smudges)
+	}
+	return strings.Join(patterns, "\n\n")
+}
+
+func Test_oracle(t *testing.T) {
+	shrinkers := []harness.Shrinker{harness.ShrinkBlocks, harness.ShrinkLines, harness.ShrinkColumns}
+	t.Run("part1", func(t *testing.T) {
+		harness.Oracle(t, func(r *rand.Rand) string { return randomPatterns(r, 0) },
+			part1, func(input string) int { return summarizeEveryLine(input, 0) }, shrinkers...)
+	})
+	t.Run("part2", func(t *testing.T) {
+		harness.Oracle(t, func(r *rand.Rand) string { return randomPatterns(r, 1) },
+			part2, func(input string) int { return summarizeEveryLine(input, 1) }, shrinkers...)
+	})
+}
@@ -1,44 +1,72 @@
 package main
 
 import (
-	_ "embed"
+	"context"
+	"embed"
 	"flag"
 	"fmt"
+	"image/color"
+	"os"
 	"strings"
-	"time"
 
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
 	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/util/grid"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
+	"github.com/Kris-Pelteshki/aoc_2023/util/visualize"
 )
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
+	opts := runner.Flags()
+	var gifFile string
+	var render bool
+	flag.StringVar(&gifFile, "gif", "", "record every tilt to a .gif (or the final platform to a .png)")
+	flag.BoolVar(&render, "render", false, "print the platform tilted north, shading rounded rocks by their load")
 	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
+
+	if render {
+		renderLoad(input)
+	}
+
+	var recorder *visualize.Recorder
+	if gifFile != "" {
+		recorder = visualize.NewRecorder(visualize.Options{
+			Palette: map[byte]color.Color{
+				byte(rounded): color.RGBA{R: 0xe0, G: 0x8a, B: 0x2c, A: 0xff},
+				byte(cube):    color.Gray{Y: 0x80},
+			},
+			Skip: 20,
+		})
+		hook = recorder
 	}
 
-	fmt.Println("Time:", time.Since(start))
+	err := runner.Run(opts, input, runner.Quick(part1), part2)
+	if recorder != nil {
+		// saved even if the part failed or timed out, to show how far it got
+		if saveErr := recorder.Save(gifFile); saveErr != nil {
+			fmt.Fprintln(os.Stderr, "saving visualization:", saveErr)
+			if err == nil {
+				os.Exit(1)
+			}
+		}
+	}
+	if err != nil {
+		runner.Exit(err)
+	}
 }
 
 type rock byte
@@ -59,23 +87,42 @@ const (
 )
 
 func part1(input string) int {
-	platform := parseInput(input)
+	platform := cast.Must(parseInput(input))
 	platform.tilt(north)
 	return platform.load()
 }
 
-// TODO:
-// learn about cycle detection algorithms
-func part2(input string) int {
-	platform := parseInput(input)
-	for i := 0; i < 1000; i++ {
-		platform.cycle()
+// part2 spins the platform until it's back in a position it's been in, then
+// skips the whole loops left before the billionth cycle
+func part2(ctx context.Context, input string) int {
+	platform := cast.Must(parseInput(input))
+	const cycles = 1_000_000_000
+	seen := map[string]int{}
+	for i := 0; i < cycles; i++ {
+		key := platform.String()
+		if first, ok := seen[key]; ok {
+			period := i - first
+			for left := (cycles - i) % period; left > 0; left-- {
+				if err := platform.cycle(ctx); err != nil {
+					return 0
+				}
+			}
+			break
+		}
+		seen[key] = i
+		if err := platform.cycle(ctx); err != nil {
+			return 0
+		}
 	}
 	return platform.load()
 }
 
-func parseInput(input string) (p platform) {
-	for _, line := range util.SplitLines(input) {
+func parseInput(input string) (p platform, err error) {
+	lines := util.SplitLines(input)
+	if err := grid.CheckRows(lines, "O#."); err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
 		row := make([]rock, len(line))
 		for i := range line {
 			switch line[i] {
@@ -89,14 +136,50 @@ func parseInput(input string) (p platform) {
 		}
 		p = append(p, row)
 	}
-	return p
+	return p, nil
 }
 
-func (p *platform) cycle() {
-	p.tilt(north)
-	p.tilt(west)
-	p.tilt(south)
-	p.tilt(east)
+// hook records the platform after every tilt when set
+var hook visualize.Hook
+
+// cycle tilts the platform north, west, south and east, unless ctx is done
+func (p *platform) cycle(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, dir := range []direction{north, west, south, east} {
+		p.tilt(dir)
+		if hook != nil {
+			hook.Frame(p.grid(), nil)
+		}
+	}
+	return nil
+}
+
+func renderLoad(input string) {
+	platform := cast.Must(parseInput(input))
+	platform.tilt(north)
+
+	loads := make(map[grid.Point]int)
+	for y, row := range platform {
+		for x, r := range row {
+			if r == rounded {
+				loads[grid.Point{X: x, Y: y}] = len(platform) - y
+			}
+		}
+	}
+	renderer := grid.Renderer{
+		Layers: []grid.Layer{&grid.ValueLayer{Values: loads}},
+	}
+	renderer.Print(platform.grid())
+}
+
+func (p *platform) grid() *grid.Grid {
+	rows := make([]string, len(*p))
+	for i, row := range *p {
+		rows[i] = string(row)
+	}
+	return grid.FromRows(rows)
 }
 
 // Tilt the platform in the given direction and return the new platform
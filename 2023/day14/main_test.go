@@ -1,7 +1,15 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/grid"
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
 )
 
 var example = `O....#....
@@ -15,6 +23,29 @@ O.#..O.#.#
 #....###..
 #OO..#....`
 
+// longLoop settles into a loop of 16 cycles, which doesn't divide the
+// 999,999,000 left after the first 1000. The loops of small random platforms
+// almost always do, so 1000 cycles gets their load right by luck.
+var longLoop = `.......#............
+........#...#.......
+...#.........#......
+...#..............#.
+..#...#...........#.
+.#........#.........
+....#...............
+#..............#...#
+O..#O....O#....O..O.
+..#...O##.O#.O..OO..
+#..OO....O#.........
+.#.O#O..#....O#O.#O.
+......#O.O.O....O.O#
+.#.#.#...OO..O..O.O.
+...#....#O.O.#....O.
+O#O.O...O.#...O.#...
+#OO..O......#O...OO.
+.....#....O#...O.O..
+..#O#...O..O..O....#`
+
 func Test_part1(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -52,6 +83,11 @@ func Test_part2(t *testing.T) {
 			input: example,
 			want:  64,
 		},
+		{
+			name:  "loop of 16 cycles",
+			input: longLoop,
+			want:  454,
+		},
 		// {
 		// 	name:  "actual",
 		// 	input: input,
@@ -60,9 +96,124 @@ func Test_part2(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := part2(tt.input); got != tt.want {
+			if got := part2(context.Background(), tt.input); got != tt.want {
 				t.Errorf("part2() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_part2_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := part2(ctx, example); got != 0 {
+		t.Errorf("part2() after cancelling = %v, want 0", got)
+	}
+}
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, runner.Background(part2))
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, parseInput)
+	})
+}
+
+// randomPlatform makes a size x size platform
+func randomPlatform(r *rand.Rand, size int) string {
+	return harness.RandomGrid(r, size, size, "...OO#")
+}
+
+// rollOneStep moves every rounded rock that can go a step in the direction
+// dx, dy until none can
+func rollOneStep(rows [][]byte, dx, dy int) {
+	for moved := true; moved; {
+		moved = false
+		for y := range rows {
+			for x := range rows[y] {
+				nx, ny := x+dx, y+dy
+				if rows[y][x] != 'O' || ny < 0 || ny >= len(rows) || nx < 0 || nx >= len(rows[y]) || rows[ny][nx] != '.' {
+					continue
+				}
+				rows[y][x], rows[ny][nx] = '.', 'O'
+				moved = true
+			}
+		}
+	}
+}
+
+func northLoad(rows [][]byte) (load int) {
+	for y, row := range rows {
+		load += strings.Count(string(row), "O") * (len(rows) - y)
+	}
+	return load
+}
+
+func parseRows(input string) (rows [][]byte) {
+	if err := grid.CheckRows(strings.Split(input, "\n"), "O#."); err != nil {
+		panic(err)
+	}
+	for _, line := range strings.Split(input, "\n") {
+		rows = append(rows, []byte(line))
+	}
+	return rows
+}
+
+func rollingPart1(input string) int {
+	rows := parseRows(input)
+	rollOneStep(rows, 0, -1)
+	return northLoad(rows)
+}
+
+// rollingPart2 spins a step at a time until the platform repeats, then skips
+// ahead whole loops to the billionth cycle
+func rollingPart2(input string) int {
+	rows := parseRows(input)
+	seen := map[string]int{}
+	const cycles = 1000000000
+	for i := 0; i < cycles; i++ {
+		key := string(bytes.Join(rows, []byte("\n")))
+		if start, ok := seen[key]; ok {
+			remaining := (cycles - i) % (i - start)
+			for j := 0; j < remaining; j++ {
+				spin(rows)
+			}
+			break
+		}
+		seen[key] = i
+		spin(rows)
+	}
+	return northLoad(rows)
+}
+
+func spin(rows [][]byte) {
+	rollOneStep(rows, 0, -1)
+	rollOneStep(rows, -1, 0)
+	rollOneStep(rows, 0, 1)
+	rollOneStep(rows, 1, 0)
+}
+
+// Test_oracle/part2 can't tell skipping ahead from stopping after a fixed
+// number of cycles, as its platforms loop every few cycles, see longLoop
+func Test_oracle(t *testing.T) {
+	small := func(r *rand.Rand) string { return randomPlatform(r, 1+r.Intn(8)) }
+	t.Run("part1", func(t *testing.T) {
+		harness.Oracle(t, small, part1, rollingPart1, harness.ShrinkLines, harness.ShrinkColumns)
+	})
+	t.Run("part2", func(t *testing.T) {
+		harness.Oracle(t, small, runner.Background(part2), rollingPart2, harness.ShrinkLines, harness.ShrinkColumns)
+	})
+}
+
+func Benchmark_scaling(b *testing.B) {
+	b.Run("part1", func(b *testing.B) {
+		harness.Scaling(b, randomPlatform, part1, harness.Linear)
+	})
+	b.Run("part2", func(b *testing.B) {
+		harness.Scaling(b, randomPlatform, runner.Background(part2), harness.Linear, 20, 40, 80, 160)
+	})
+}
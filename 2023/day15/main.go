@@ -1,46 +1,31 @@
 package main
 
 import (
-	_ "embed"
-	"flag"
+	"embed"
 	"fmt"
 	"slices"
 	"strings"
-	"time"
 
 	"github.com/Kris-Pelteshki/aoc_2023/cast"
-	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
-	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
-
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	}
-
-	fmt.Println("Time:", time.Since(start))
+	runner.Main(input, runner.Quick(part1), runner.Quick(part2))
 }
 
 func part1(input string) (total int) {
@@ -54,7 +39,7 @@ func part1(input string) (total int) {
 
 func part2(input string) (total int) {
 	Boxes := make([]Box, 256)
-	operations := parseOperations(input)
+	operations := cast.Must(parseOperations(input))
 
 	for _, op := range operations {
 		boxNum := hash(op.Label)
@@ -114,19 +99,24 @@ func hash(s string) int {
 	return currentValue
 }
 
-func parseOperations(input string) (operations []Operation) {
+func parseOperations(input string) (operations []Operation, err error) {
 	for _, s := range strings.Split(input, ",") {
 		operation := Operation{}
 
-		if strings.HasSuffix(s, "-") {
+		if label, ok := strings.CutSuffix(s, "-"); ok {
 			operation.Operation = "-"
-			operation.Label = s[:len(s)-1]
-		} else {
+			operation.Label = label
+		} else if label, focalLength, ok := strings.Cut(s, "="); ok {
 			operation.Operation = "="
-			operation.Label = s[:len(s)-2]
-			operation.FocalLength = cast.ToInt(s[len(s)-1:])
+			operation.Label = label
+			operation.FocalLength, err = cast.Parse[int](focalLength)
+			if err != nil {
+				return nil, fmt.Errorf("step %q: %w", s, err)
+			}
+		} else {
+			return nil, fmt.Errorf("step %q is neither - nor =", s)
 		}
 		operations = append(operations, operation)
 	}
-	return operations
+	return operations, nil
 }
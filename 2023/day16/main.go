@@ -1,55 +1,86 @@
 package main
 
 import (
-	_ "embed"
+	"context"
+	"embed"
 	"flag"
 	"fmt"
+	"image/color"
+	"math"
+	"os"
 	"strings"
-	"time"
 
-	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+	gridutil "github.com/Kris-Pelteshki/aoc_2023/util/grid"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
+	"github.com/Kris-Pelteshki/aoc_2023/util/visualize"
 	"github.com/emirpasic/gods/queues/arrayqueue"
 )
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
+	opts := runner.Flags()
+	var gifFile string
+	var render bool
+	flag.StringVar(&gifFile, "gif", "", "record the part 1 beam to a .gif (or the energized tiles to a .png)")
+	flag.BoolVar(&render, "render", false, "print the contraption with the tiles part 1's beam energizes highlighted")
 	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
 
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
+	if render {
+		renderEnergized(input)
+	}
+
+	// part 2 simulates beams concurrently, which would interleave frames
+	var recorder *visualize.Recorder
+	if gifFile != "" && opts.Part == 1 {
+		recorder = visualize.NewRecorder(visualize.Options{
+			Palette: map[byte]color.Color{
+				backMirror:         color.Gray{Y: 0xa0},
+				forwardMirror:      color.Gray{Y: 0xa0},
+				verticalSplitter:   color.RGBA{R: 0x40, G: 0x80, B: 0xff, A: 0xff},
+				horizontalSplitter: color.RGBA{R: 0x40, G: 0x80, B: 0xff, A: 0xff},
+			},
+			Skip: 10,
+		})
+		hook = recorder
 	}
 
-	fmt.Println("Time:", time.Since(start))
+	err := runner.Run(opts, input, part1, part2)
+	if recorder != nil {
+		// saved even if the part failed or timed out, to show how far it got
+		if saveErr := recorder.Save(gifFile); saveErr != nil {
+			fmt.Fprintln(os.Stderr, "saving visualization:", saveErr)
+			if err == nil {
+				os.Exit(1)
+			}
+		}
+	}
+	if err != nil {
+		runner.Exit(err)
+	}
 }
 
-func part1(input string) int {
-	grid := parseInput(input)
-	visitedPointsCount := grid.simulateBeam(Beam{0, 0, Right})
+func part1(ctx context.Context, input string) int {
+	grid := cast.Must(parseInput(input))
+	visitedPointsCount := grid.simulateBeam(ctx, Beam{0, 0, Right})
 	return visitedPointsCount
 }
 
-func part2(input string) int {
-	grid := parseInput(input)
+func part2(ctx context.Context, input string) int {
+	grid := cast.Must(parseInput(input))
 	maxVisitedTiles := 0
 	entryPoints := []Beam{}
 
@@ -69,7 +100,7 @@ func part2(input string) int {
 
 	for _, beam := range entryPoints {
 		go func(beam Beam) {
-			visitedTiles := grid.simulateBeam(beam)
+			visitedTiles := grid.simulateBeam(ctx, beam)
 			beamChannel <- visitedTiles
 		}(beam)
 	}
@@ -124,8 +155,15 @@ type Grid struct {
 	Cells         [][]rune
 }
 
-func parseInput(input string) *Grid {
+func parseInput(input string) (*Grid, error) {
 	lines := strings.Split(input, "\n")
+	if err := gridutil.CheckRows(lines, `.|-/\`); err != nil {
+		return nil, err
+	}
+	// sizes are uint8 to keep beam states small
+	if len(lines) > math.MaxUint8 || len(lines[0]) > math.MaxUint8 {
+		return nil, fmt.Errorf("%dx%d grid is over %d wide or tall", len(lines[0]), len(lines), math.MaxUint8)
+	}
 	grid := &Grid{
 		Height: uint8(len(lines)),
 		Width:  uint8(len(lines[0])),
@@ -135,17 +173,61 @@ func parseInput(input string) *Grid {
 	for i, line := range lines {
 		grid.Cells[i] = []rune(line)
 	}
-	return grid
+	return grid, nil
+}
+
+func (g *Grid) toGrid() *gridutil.Grid {
+	rows := make([]string, len(g.Cells))
+	for i, row := range g.Cells {
+		rows[i] = string(row)
+	}
+	return gridutil.FromRows(rows)
 }
 
 func (g *Grid) isInside(x, y uint8) bool {
 	return x < g.Width && y < g.Height
 }
 
-func (grid *Grid) simulateBeam(b Beam) int {
+// hook records the energized tiles after every beam step when set
+var hook visualize.Hook
+
+// energizedHook keeps the energized tiles from the beam's frames. The beam
+// only ever adds to them, so the last set it was given is every tile.
+type energizedHook struct {
+	tiles map[gridutil.Point]bool
+}
+
+func (h *energizedHook) Frame(_ *gridutil.Grid, highlight map[gridutil.Point]bool) {
+	h.tiles = highlight
+}
+
+func renderEnergized(input string) {
+	grid := cast.Must(parseInput(input))
+	energized := &energizedHook{}
+	hook = energized
+	grid.simulateBeam(context.Background(), Beam{0, 0, Right})
+	hook = nil
+
+	renderer := gridutil.Renderer{
+		Layers: []gridutil.Layer{gridutil.SetLayer{Points: energized.tiles, Color: gridutil.Yellow}},
+	}
+	renderer.Print(grid.toGrid())
+}
+
+// simulateBeam counts the tiles energized by a beam, or gives up with 0 once
+// ctx is done
+func (grid *Grid) simulateBeam(ctx context.Context, b Beam) int {
 	beam := &b
+	done := ctx.Done()
 	energizedTiles := 0
 
+	var frameGrid *gridutil.Grid
+	var energized map[gridutil.Point]bool
+	if hook != nil {
+		frameGrid = grid.toGrid()
+		energized = make(map[gridutil.Point]bool)
+	}
+
 	visited := make([][][4]bool, grid.Height)
 	for i := range visited {
 		visited[i] = make([][4]bool, grid.Width)
@@ -155,6 +237,12 @@ func (grid *Grid) simulateBeam(b Beam) int {
 	queue.Enqueue(beam)
 
 	for !queue.Empty() {
+		select {
+		case <-done:
+			return 0
+		default:
+		}
+
 		elem, _ := queue.Dequeue()
 		beam = elem.(*Beam)
 
@@ -176,6 +264,11 @@ func (grid *Grid) simulateBeam(b Beam) int {
 			}
 		}
 
+		if hook != nil {
+			energized[gridutil.Point{X: int(beam.X), Y: int(beam.Y)}] = true
+			hook.Frame(frameGrid, energized)
+		}
+
 		switch grid.Cells[beam.Y][beam.X] {
 		case backMirror:
 			switch beam.Direction {
@@ -1,7 +1,12 @@
 package main
 
 import (
+	"context"
+	"math/rand"
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
 )
 
 var example = `.|...\....
@@ -29,7 +34,7 @@ func Test_part1(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := part1(tt.input); got != tt.want {
+			if got := part1(context.Background(), tt.input); got != tt.want {
 				t.Errorf("part1() = %v, want %v", got, tt.want)
 			}
 		})
@@ -55,9 +60,43 @@ func Test_part2(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := part2(tt.input); got != tt.want {
+			if got := part2(context.Background(), tt.input); got != tt.want {
 				t.Errorf("part2() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_part2_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := part2(ctx, example); got != 0 {
+		t.Errorf("part2() after cancelling = %v, want 0", got)
+	}
+}
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, runner.Background(part1), runner.Background(part2))
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, parseInput)
+	})
+}
+
+// randomContraption makes a size x size grid, at most 255 wide, that's mostly
+// empty space with mirrors and splitters
+func randomContraption(r *rand.Rand, size int) string {
+	size = min(size, 255)
+	return harness.RandomGrid(r, size, size, `............/\\|-`)
+}
+
+// Only part 2 is measured, part 1's one beam can be stuck in a corner of a
+// random grid or light up all of it. Part 2 fires a beam from every edge tile
+// through the whole grid, so it grows faster than the input.
+func Benchmark_scaling(b *testing.B) {
+	harness.Scaling(b, randomContraption, runner.Background(part2), harness.Quadratic, 15, 30, 60, 120)
+}
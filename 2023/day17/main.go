@@ -1,61 +1,159 @@
 package main
 
 import (
-	_ "embed"
+	"embed"
 	"flag"
 	"fmt"
-	"strings"
-	"time"
 
 	"github.com/Kris-Pelteshki/aoc_2023/cast"
 	"github.com/Kris-Pelteshki/aoc_2023/util"
+	gridutil "github.com/Kris-Pelteshki/aoc_2023/util/grid"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+	"github.com/Kris-Pelteshki/aoc_2023/util/search"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
 )
 
-//go:embed input.txt
+// input.txt, or input.txt.enc which is decrypted with $AOC_VAULT_KEY
+//
+//go:embed input.txt*
+var inputFiles embed.FS
+
 var input string
 
 func init() {
 	// do this in init (not main) so test file has same input
-	input = strings.TrimRight(input, "\n")
-	if len(input) == 0 {
-		panic("empty input.txt file")
-	}
+	input = inputs.Normalize(vault.Input(inputFiles))
 }
 
 func main() {
-	var part int
-	flag.IntVar(&part, "part", 1, "part 1 or 2")
+	opts := runner.Flags()
+	var render bool
+	flag.BoolVar(&render, "render", false, "print the city with the crucible's path highlighted")
 	flag.Parse()
-	fmt.Println("Running part", part)
-	start := time.Now()
 
-	if part == 1 {
-		ans := part1(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
-	} else {
-		ans := part2(input)
-		util.CopyToClipboard(fmt.Sprintf("%v", ans))
-		fmt.Println("Output:", ans)
+	if err := runner.Run(opts, input, runner.Quick(part1), runner.Quick(part2)); err != nil {
+		runner.Exit(err)
 	}
+	// after the part, so a search that's too slow has already timed out
+	if render {
+		renderPath(input, crucibles[opts.Part-1])
+	}
+}
 
-	fmt.Println("Time:", time.Since(start))
+type Point = gridutil.Point
+
+// crucible is how many blocks a crucible has to go in a line before it can
+// turn or stop, and how many it can go before it has to turn
+type crucible struct {
+	minRun, maxRun int
 }
 
-func part1(input string) int {
-	parsed := parseInput(input)
-	_ = parsed
+var crucibles = [2]crucible{
+	{minRun: 1, maxRun: 3},
+	{minRun: 4, maxRun: 10},
+}
 
-	return 0
+func part1(input string) int {
+	city := cast.Must(parseInput(input))
+	_, heatLoss, err := crucibles[0].leastHeatLoss(city)
+	if err != nil {
+		panic(err)
+	}
+	return heatLoss
 }
 
 func part2(input string) int {
-	return 0
+	city := cast.Must(parseInput(input))
+	_, heatLoss, err := crucibles[1].leastHeatLoss(city)
+	if err != nil {
+		panic(err)
+	}
+	return heatLoss
+}
+
+func parseInput(input string) (*gridutil.Grid, error) {
+	rows := util.SplitLines(input)
+	if err := gridutil.CheckRows(rows, "123456789"); err != nil {
+		return nil, err
+	}
+	return gridutil.FromRows(rows), nil
+}
+
+// headings are right, down, left and up, so turning right is the next one
+var headings = [4]Point{{X: 1}, {Y: 1}, {X: -1}, {Y: -1}}
+
+// state is where a crucible is, which way it's heading and how many blocks
+// it's moved that way
+type state struct {
+	pos     Point
+	heading int
+	run     int
+}
+
+// leastHeatLoss finds the path from the top left to the bottom right block
+// that loses the least heat, and returns the blocks along it. It's an error
+// if the city's too narrow for the crucible to get there.
+func (c crucible) leastHeatLoss(city *gridutil.Grid) ([]Point, int, error) {
+	end := Point{X: city.Width - 1, Y: city.Height - 1}
+	// facing right without having moved, it can go right or turn down
+	start := state{heading: 0, run: 0}
+	if start.pos == end {
+		// it's already there, without moving at all
+		return []Point{end}, 0, nil
+	}
+
+	neighbors := func(s state) []search.Edge[state] {
+		var edges []search.Edge[state]
+		for turn := -1; turn <= 1; turn++ {
+			run := s.run + 1
+			if turn != 0 {
+				// only the start has a run of 0, it can turn straight away
+				if s.run > 0 && s.run < c.minRun {
+					continue
+				}
+				run = 1
+			}
+			if run > c.maxRun {
+				continue
+			}
+
+			heading := (s.heading + turn + 4) % 4
+			next := Point{X: s.pos.X + headings[heading].X, Y: s.pos.Y + headings[heading].Y}
+			if !city.InBounds(next) {
+				continue
+			}
+			edges = append(edges, search.Edge[state]{
+				To:   state{pos: next, heading: heading, run: run},
+				Cost: int(city.At(next) - '0'),
+			})
+		}
+		return edges
+	}
+	stops := func(s state) bool {
+		return s.pos == end && s.run >= c.minRun
+	}
+
+	states, heatLoss, ok := search.Dijkstra([]state{start}, neighbors, stops)
+	if !ok {
+		return nil, 0, fmt.Errorf("a crucible that goes %d to %d blocks can't reach the end", c.minRun, c.maxRun)
+	}
+	path := make([]Point, len(states))
+	for i, s := range states {
+		path[i] = s.pos
+	}
+	return path, heatLoss, nil
 }
 
-func parseInput(input string) (ans []int) {
-	for _, line := range strings.Split(input, "\n") {
-		ans = append(ans, cast.ToInt(line))
+func renderPath(input string, c crucible) {
+	city := cast.Must(parseInput(input))
+	path, _, err := c.leastHeatLoss(city)
+	if err != nil {
+		panic(err)
+	}
+
+	renderer := gridutil.Renderer{
+		Layers: []gridutil.Layer{&gridutil.PathLayer{Path: path, Color: gridutil.Red}},
 	}
-	return ans
+	renderer.Print(city)
 }
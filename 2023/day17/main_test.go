@@ -2,9 +2,33 @@ package main
 
 import (
 	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+	"github.com/Kris-Pelteshki/aoc_2023/util/harness"
+	"github.com/Kris-Pelteshki/aoc_2023/util/maths"
 )
 
-var example = ``
+var example = `2413432311323
+3215453535623
+3255245654254
+3446585845452
+4546657867536
+1438598798454
+4457876987766
+3637877979653
+4654967986887
+4564679986453
+1224686865563
+2546548887735
+4322674655533`
+
+// example2 is unlucky for the ultra crucible, which can't turn in time to
+// take the cheap 1s along the edges
+var example2 = `111111111111
+999999999991
+999999999991
+999999999991
+999999999991`
 
 func Test_part1(t *testing.T) {
 	tests := []struct {
@@ -15,7 +39,7 @@ func Test_part1(t *testing.T) {
 		{
 			name:  "example",
 			input: example,
-			want:  0,
+			want:  102,
 		},
 		// {
 		// 	name:  "actual",
@@ -41,7 +65,12 @@ func Test_part2(t *testing.T) {
 		{
 			name:  "example",
 			input: example,
-			want:  0,
+			want:  94,
+		},
+		{
+			name:  "example2",
+			input: example2,
+			want:  71,
 		},
 		// {
 		// 	name:  "actual",
@@ -57,3 +86,74 @@ func Test_part2(t *testing.T) {
 		})
 	}
 }
+
+func Test_leastHeatLoss(t *testing.T) {
+	city := cast.Must(parseInput(example))
+	for _, c := range crucibles {
+		path, heatLoss, err := c.leastHeatLoss(city)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if start, end := path[0], path[len(path)-1]; start != (Point{}) || end != (Point{X: 12, Y: 12}) {
+			t.Errorf("%+v path goes from %v to %v, want the corners", c, start, end)
+		}
+
+		// the start block's heat isn't lost, the crucible is already there
+		lost := 0
+		for i, p := range path[1:] {
+			if maths.Abs(p.X-path[i].X)+maths.Abs(p.Y-path[i].Y) != 1 {
+				t.Fatalf("%+v path jumps from %v to %v", c, path[i], p)
+			}
+			lost += int(city.At(p) - '0')
+		}
+		if lost != heatLoss {
+			t.Errorf("%+v path loses %d heat, want %d", c, lost, heatLoss)
+		}
+	}
+}
+
+func Test_leastHeatLoss_small(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		c       crucible
+		want    int
+		wantErr bool
+	}{
+		{"one block", "7", crucibles[0], 0, false},
+		{"one block ultra", "7", crucibles[1], 0, false},
+		{"one row", "123", crucibles[0], 5, false},
+		{"one row too short for ultra", "123", crucibles[1], 0, true},
+		{"one row ultra", "12345", crucibles[1], 14, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			city := cast.Must(parseInput(tt.input))
+			_, got, err := tt.c.leastHeatLoss(city)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("leastHeatLoss() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("leastHeatLoss() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_inputs checks the parts against every named input in inputs/
+func Test_inputs(t *testing.T) {
+	harness.Test(t, part1, part2)
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		harness.CheckParse(t, input, parseInput)
+	})
+}
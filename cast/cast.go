@@ -8,15 +8,14 @@ import (
 	"strconv"
 )
 
-// ToInt will case a given arg into an int type.
+// ToInt will case a given arg into an int type, panicking on failure. Use
+// Parse for an error instead, or for other sized types.
 // Supported types are:
 //   - string
 func ToInt(arg interface{}) int {
 	switch v := arg.(type) {
 	case string:
-		var val int
-		var err error
-		val, err = strconv.Atoi(v)
+		val, err := Parse[int](v)
 		if err != nil {
 			panic("error converting string to int " + err.Error())
 		}
@@ -59,23 +58,10 @@ const (
 	ASCIICodeLowerZ = int('z') // 97
 )
 
-// ToASCIICode returns the ascii code of a given input
+// ToASCIICode returns the ascii code of a given input, panicking for
+// unsupported types. See ASCIICode.
 func ToASCIICode(arg interface{}) int {
-	var asciiVal int
-	switch arg.(type) {
-	case string:
-		str := arg.(string)
-		if len(str) != 1 {
-			panic("can only convert ascii Code for string of length 1")
-		}
-		asciiVal = int(str[0])
-	case byte:
-		asciiVal = int(arg.(byte))
-	case rune:
-		asciiVal = int(arg.(rune))
-	}
-
-	return asciiVal
+	return Must(ASCIICode(arg))
 }
 
 // ASCIIIntToChar returns a one character string of the given int
@@ -1,6 +1,9 @@
 package cast_test
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Kris-Pelteshki/aoc_2023/cast"
@@ -92,3 +95,171 @@ func TestASCIIIntToChar(t *testing.T) {
 		})
 	}
 }
+
+func TestParse(t *testing.T) {
+	intTests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"123", 123, false},
+		{"-42", -42, false},
+		{"+7", 7, false},
+		{"010", 10, false},
+		{"0x1f", 31, false},
+		{"-0b101", -5, false},
+		{"0o17", 15, false},
+		{"0x-5", 0, true},
+		{"0b+1", 0, true},
+		{"", 0, true},
+		{"12a", 0, true},
+	}
+	for _, tt := range intTests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := cast.Parse[int](tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse[int](%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse[int](%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if got := cast.MustParse[int64]("4000000000000"); got != 4000000000000 {
+		t.Errorf("MustParse[int64] = %v, want 4000000000000", got)
+	}
+	if got := cast.MustParse[uint]("+3"); got != 3 {
+		t.Errorf("MustParse[uint] = %v, want 3", got)
+	}
+	if _, err := cast.Parse[uint]("-3"); err == nil {
+		t.Errorf("Parse[uint](-3) expected an error")
+	}
+	if _, err := cast.Parse[uint]("0x+3"); err == nil {
+		t.Errorf("Parse[uint](0x+3) expected an error, the sign goes before the prefix")
+	}
+	if _, err := cast.ParseBig("0x-5"); err == nil {
+		t.Errorf("ParseBig(0x-5) expected an error, the sign goes before the prefix")
+	}
+	if _, err := cast.Parse[int8]("200"); err == nil {
+		t.Errorf("Parse[int8](200) expected an out of range error")
+	}
+	if got := cast.MustParse[float64]("-1.5"); got != -1.5 {
+		t.Errorf("MustParse[float64] = %v, want -1.5", got)
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	got, err := cast.ParseAll[uint]([]string{"1", "2", "0x10"})
+	if err != nil || len(got) != 3 || got[2] != 16 {
+		t.Errorf("ParseAll[uint]() = %v, %v", got, err)
+	}
+	if _, err := cast.ParseAll[int]([]string{"1", "x"}); err == nil {
+		t.Errorf("ParseAll[int]() expected an error")
+	}
+}
+
+func TestParseBig(t *testing.T) {
+	got, err := cast.ParseBig("-123456789012345678901234567890")
+	if err != nil || got.String() != "-123456789012345678901234567890" {
+		t.Errorf("ParseBig() = %v, %v", got, err)
+	}
+	if got := cast.Must(cast.ParseBig("0xff")); got.Int64() != 255 {
+		t.Errorf("ParseBig(0xff) = %v, want 255", got)
+	}
+	if _, err := cast.ParseBig("1.5"); err == nil {
+		t.Errorf("ParseBig(1.5) expected an error")
+	}
+}
+
+func TestASCIICode(t *testing.T) {
+	if got, err := cast.ASCIICode("a"); err != nil || got != 97 {
+		t.Errorf("ASCIICode(a) = %v, %v", got, err)
+	}
+	if _, err := cast.ASCIICode(1.5); err == nil {
+		t.Errorf("ASCIICode(1.5) expected an error")
+	}
+	if _, err := cast.ASCIICode("ab"); err == nil {
+		t.Errorf("ASCIICode(ab) expected an error")
+	}
+}
+
+func TestExtractInts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []int
+	}{
+		{"card", "Card 1: 41 48 | 83 86", []int{1, 41, 48, 83, 86}},
+		{"negatives", "x=-3, y=12 -7", []int{-3, 12, -7}},
+		{"dash between numbers", "5-3", []int{5, -3}},
+		{"lone dash", "a - 4", []int{4}},
+		{"none", "no numbers here", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cast.ExtractInts(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractInts(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	got := cast.Extract[uint]("seed-to-soil 50-98 2", cast.ExtractUnsigned)
+	if want := []uint{50, 98, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract[uint]() = %v, want %v", got, want)
+	}
+
+	got64 := cast.Extract[int64]("1,0,1~-1,2,4000000000", cast.ExtractSigned)
+	if want := []int64{1, 0, 1, -1, 2, 4000000000}; !reflect.DeepEqual(got64, want) {
+		t.Errorf("Extract[int64]() = %v, want %v", got64, want)
+	}
+
+	if _, err := cast.TryExtract[uint8]("1 2 300", cast.ExtractUnsigned); err == nil {
+		t.Error("TryExtract[uint8]() of 300 should error")
+	}
+}
+
+func TestOCR(t *testing.T) {
+	// "HIZ" in the small font and "RX" in the large one, with uneven spacing
+	small := `
+#..#..###..####
+#..#...#......#
+####...#.....#.
+#..#...#....#..
+#..#...#...#...
+#..#..###..####`
+	if got, err := cast.OCR(small); err != nil || got != "HIZ" {
+		t.Errorf("OCR(small) = %q, %v, want HIZ", got, err)
+	}
+
+	large := `........................
+.#####....#....#........
+.#....#...#....#........
+.#....#....#..#.........
+.#....#....#..#.........
+.#####......##..........
+.#..#.......##..........
+.#...#.....#..#.........
+.#...#.....#..#.........
+.#....#...#....#........
+.#....#...#....#........`
+	if got, err := cast.OCR(large); err != nil || got != "RX" {
+		t.Errorf("OCR(large) = %q, %v, want RX", got, err)
+	}
+
+	blocks := strings.NewReplacer("#", "█", ".", " ").Replace(small)
+	if got, err := cast.OCRWith(blocks, '█'); err != nil || got != "HIZ" {
+		t.Errorf("OCRWith(blocks) = %q, %v, want HIZ", got, err)
+	}
+	if _, err := cast.OCRWith(blocks, '#'); err == nil {
+		t.Errorf("OCRWith() expected an error for a drawing with nothing lit")
+	}
+
+	unknown := strings.Replace(small, "####\n", "#.##\n", 1)
+	var glyphErr *cast.UnknownGlyphError
+	if _, err := cast.OCR(unknown); !errors.As(err, &glyphErr) || glyphErr.Index != 2 || glyphErr.Column != 11 {
+		t.Errorf("OCR(unknown) error = %v, want unknown glyph 2 at column 11", err)
+	}
+}
@@ -0,0 +1,55 @@
+package cast
+
+// ExtractMode decides what a - in front of a number means for Extract.
+type ExtractMode int
+
+const (
+	// ExtractSigned reads a - directly before a digit as a minus sign, so
+	// "x=-3" gives [-3] and "5-3" gives [5 -3]
+	ExtractSigned ExtractMode = iota
+	// ExtractUnsigned treats - as any other separator, so ranges like "1-3"
+	// give [1 3]
+	ExtractUnsigned
+)
+
+// ExtractInts returns every integer in s in the order they appear, ignoring
+// anything that isn't a digit or a minus sign:
+//
+//	cast.ExtractInts("Card 1: 41 48 | 83 86") // [1 41 48 83 86]
+func ExtractInts(s string) []int {
+	return Extract[int](s, ExtractSigned)
+}
+
+// Extract is ExtractInts for any integer type. Panics if a number doesn't fit
+// in T or is negative for an unsigned T.
+func Extract[T Integer](s string, mode ExtractMode) []T {
+	return Must(TryExtract[T](s, mode))
+}
+
+// TryExtract is Extract that returns an error for a number that doesn't fit.
+func TryExtract[T Integer](s string, mode ExtractMode) (nums []T, err error) {
+	for i := 0; i < len(s); {
+		start := i
+		if mode == ExtractSigned && s[i] == '-' && i+1 < len(s) && isDigit(s[i+1]) {
+			i++
+		}
+		if !isDigit(s[i]) {
+			i++
+			continue
+		}
+
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		num, err := Parse[T](s[start:i])
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, num)
+	}
+	return nums, nil
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,185 @@
+package cast
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AOC draws some answers as capital letters made of # and . characters. There
+// are two fonts, 6 rows tall (mostly 4 columns wide) and 10 rows tall (mostly
+// 6 columns wide). Glyphs are stored here trimmed to their lit columns.
+var (
+	font6  = map[string]string{}
+	font10 = map[string]string{}
+)
+
+func init() {
+	glyphs6 := map[string]string{
+		"A": ".##.\n#..#\n#..#\n####\n#..#\n#..#",
+		"B": "###.\n#..#\n###.\n#..#\n#..#\n###.",
+		"C": ".##.\n#..#\n#...\n#...\n#..#\n.##.",
+		"E": "####\n#...\n###.\n#...\n#...\n####",
+		"F": "####\n#...\n###.\n#...\n#...\n#...",
+		"G": ".##.\n#..#\n#...\n#.##\n#..#\n.###",
+		"H": "#..#\n#..#\n####\n#..#\n#..#\n#..#",
+		"I": ".###\n..#.\n..#.\n..#.\n..#.\n.###",
+		"J": "..##\n...#\n...#\n...#\n#..#\n.##.",
+		"K": "#..#\n#.#.\n##..\n#.#.\n#.#.\n#..#",
+		"L": "#...\n#...\n#...\n#...\n#...\n####",
+		"O": ".##.\n#..#\n#..#\n#..#\n#..#\n.##.",
+		"P": "###.\n#..#\n#..#\n###.\n#...\n#...",
+		"R": "###.\n#..#\n#..#\n###.\n#.#.\n#..#",
+		"S": ".###\n#...\n#...\n.##.\n...#\n###.",
+		"U": "#..#\n#..#\n#..#\n#..#\n#..#\n.##.",
+		"Y": "#...#\n#...#\n.#.#.\n..#..\n..#..\n..#..",
+		"Z": "####\n...#\n..#.\n.#..\n#...\n####",
+	}
+	glyphs10 := map[string]string{
+		"A": "..##..\n.#..#.\n#....#\n#....#\n#....#\n######\n#....#\n#....#\n#....#\n#....#",
+		"B": "#####.\n#....#\n#....#\n#....#\n#####.\n#....#\n#....#\n#....#\n#....#\n#####.",
+		"C": ".####.\n#....#\n#.....\n#.....\n#.....\n#.....\n#.....\n#.....\n#....#\n.####.",
+		"E": "######\n#.....\n#.....\n#.....\n#####.\n#.....\n#.....\n#.....\n#.....\n######",
+		"F": "######\n#.....\n#.....\n#.....\n#####.\n#.....\n#.....\n#.....\n#.....\n#.....",
+		"G": ".####.\n#....#\n#.....\n#.....\n#.....\n#..###\n#....#\n#....#\n#...##\n.###.#",
+		"H": "#....#\n#....#\n#....#\n#....#\n######\n#....#\n#....#\n#....#\n#....#\n#....#",
+		"J": "...###\n....#.\n....#.\n....#.\n....#.\n....#.\n....#.\n#...#.\n#...#.\n.###..",
+		"K": "#....#\n#...#.\n#..#..\n#.#...\n##....\n##....\n#.#...\n#..#..\n#...#.\n#....#",
+		"L": "#.....\n#.....\n#.....\n#.....\n#.....\n#.....\n#.....\n#.....\n#.....\n######",
+		"N": "#....#\n##...#\n##...#\n#.#..#\n#.#..#\n#..#.#\n#..#.#\n#...##\n#...##\n#....#",
+		"P": "#####.\n#....#\n#....#\n#....#\n#####.\n#.....\n#.....\n#.....\n#.....\n#.....",
+		"R": "#####.\n#....#\n#....#\n#....#\n#####.\n#..#..\n#...#.\n#...#.\n#....#\n#....#",
+		"X": "#....#\n#....#\n.#..#.\n.#..#.\n..##..\n..##..\n.#..#.\n.#..#.\n#....#\n#....#",
+		"Z": "######\n.....#\n.....#\n....#.\n...#..\n..#...\n.#....\n#.....\n#.....\n######",
+	}
+
+	for letter, glyph := range glyphs6 {
+		rows := runeRows(glyph)
+		font6[glyphKey(rows, 0, len(rows[0]), '#')] = letter
+	}
+	for letter, glyph := range glyphs10 {
+		rows := runeRows(glyph)
+		font10[glyphKey(rows, 0, len(rows[0]), '#')] = letter
+	}
+}
+
+// UnknownGlyphError is returned by OCR for a glyph that isn't in the font.
+type UnknownGlyphError struct {
+	Index  int // 0 based position of the letter in the answer
+	Column int // 0 based column the glyph starts at
+	Glyph  string
+}
+
+func (e *UnknownGlyphError) Error() string {
+	return fmt.Sprintf("unknown glyph %d at column %d:\n%s", e.Index, e.Column, e.Glyph)
+}
+
+// OCR reads the letters drawn with '#' in drawing, any other character is
+// unlit. Blank rows and columns around the letters are ignored and the font
+// is picked from the height of what's left.
+func OCR(drawing string) (string, error) {
+	return OCRWith(drawing, '#')
+}
+
+// OCRWith is OCR for drawings that use another character than '#', like '█'.
+// Columns count characters rather than bytes.
+func OCRWith(drawing string, lit rune) (string, error) {
+	rows := litRows(runeRows(strings.TrimRight(drawing, "\n")), lit)
+
+	var font map[string]string
+	switch len(rows) {
+	case 6:
+		font = font6
+	case 10:
+		font = font10
+	default:
+		return "", fmt.Errorf("drawing is %d rows tall, expected 6 or 10", len(rows))
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+
+	var answer strings.Builder
+	for col := 0; col < width; {
+		if isBlankColumn(rows, col, lit) {
+			col++
+			continue
+		}
+		start := col
+		for col < width && !isBlankColumn(rows, col, lit) {
+			col++
+		}
+
+		key := glyphKey(rows, start, col, lit)
+		letter, ok := font[key]
+		if !ok {
+			return "", &UnknownGlyphError{Index: answer.Len(), Column: start, Glyph: key}
+		}
+		answer.WriteString(letter)
+	}
+	return answer.String(), nil
+}
+
+// MustOCR is OCR that panics on a drawing it can't read.
+func MustOCR(drawing string) string {
+	return Must(OCR(drawing))
+}
+
+func runeRows(drawing string) [][]rune {
+	var rows [][]rune
+	for _, row := range strings.Split(drawing, "\n") {
+		rows = append(rows, []rune(row))
+	}
+	return rows
+}
+
+// litRows drops rows without a lit character from the top and bottom
+func litRows(rows [][]rune, lit rune) [][]rune {
+	hasLit := func(row []rune) bool {
+		return slices.Contains(row, lit)
+	}
+	for len(rows) > 0 && !hasLit(rows[0]) {
+		rows = rows[1:]
+	}
+	for len(rows) > 0 && !hasLit(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+func isBlankColumn(rows [][]rune, col int, lit rune) bool {
+	for _, row := range rows {
+		if col < len(row) && row[col] == lit {
+			return false
+		}
+	}
+	return true
+}
+
+// glyphKey draws columns [start, end) of rows with # and ., trimmed to the
+// lit columns so glyphs match wherever they sit in the drawing
+func glyphKey(rows [][]rune, start, end int, lit rune) string {
+	for start < end && isBlankColumn(rows, start, lit) {
+		start++
+	}
+	for end > start && isBlankColumn(rows, end-1, lit) {
+		end--
+	}
+
+	var key strings.Builder
+	for i, row := range rows {
+		if i > 0 {
+			key.WriteByte('\n')
+		}
+		for col := start; col < end; col++ {
+			if col < len(row) && row[col] == lit {
+				key.WriteByte('#')
+			} else {
+				key.WriteByte('.')
+			}
+		}
+	}
+	return key.String()
+}
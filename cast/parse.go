@@ -0,0 +1,132 @@
+package cast
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+type Float interface {
+	~float32 | ~float64
+}
+
+// Parse parses s into any integer or float type, sized to fit T.
+// Integers may have a + or - sign and a 0x, 0o or 0b base prefix. Unlike
+// strconv with base 0, a plain leading zero is still decimal, so "010" is 10.
+func Parse[T Integer | Float](s string) (T, error) {
+	var zero T
+	typ := reflect.TypeOf(zero)
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		digits, base := splitBasePrefix(s)
+		val, err := strconv.ParseInt(digits, base, typ.Bits())
+		if err != nil {
+			return zero, fmt.Errorf("parsing %q as %s: %w", s, typ, err)
+		}
+		return T(val), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		digits, base := splitBasePrefix(s)
+		// ParseUint has no sign handling, let a leading + through
+		digits = strings.TrimPrefix(digits, "+")
+		val, err := strconv.ParseUint(digits, base, typ.Bits())
+		if err != nil {
+			return zero, fmt.Errorf("parsing %q as %s: %w", s, typ, err)
+		}
+		return T(val), nil
+	default:
+		val, err := strconv.ParseFloat(s, typ.Bits())
+		if err != nil {
+			return zero, fmt.Errorf("parsing %q as %s: %w", s, typ, err)
+		}
+		return T(val), nil
+	}
+}
+
+// ParseAll parses every string in strs, stopping at the first error.
+func ParseAll[T Integer | Float](strs []string) ([]T, error) {
+	vals := make([]T, 0, len(strs))
+	for i, s := range strs {
+		val, err := Parse[T](s)
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %w", i, err)
+		}
+		vals = append(vals, val)
+	}
+	return vals, nil
+}
+
+// ParseBig parses an integer of any size, with the same sign and prefix
+// rules as Parse.
+func ParseBig(s string) (*big.Int, error) {
+	digits, base := splitBasePrefix(s)
+	val, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, fmt.Errorf("parsing %q as big.Int: invalid syntax", s)
+	}
+	return val, nil
+}
+
+// Must panics if err is not nil, otherwise returns val. For wrapping any of
+// the error returning functions: cast.Must(cast.Parse[uint](s))
+func Must[T any](val T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// MustParse is Parse that panics on failure.
+func MustParse[T Integer | Float](s string) T {
+	return Must(Parse[T](s))
+}
+
+// MustParseAll is ParseAll that panics on failure.
+func MustParseAll[T Integer | Float](strs []string) []T {
+	return Must(ParseAll[T](strs))
+}
+
+// ASCIICode returns the ascii code of a one character string, byte or rune.
+func ASCIICode(arg interface{}) (int, error) {
+	switch v := arg.(type) {
+	case string:
+		if len(v) != 1 {
+			return 0, fmt.Errorf("can only convert ascii code for string of length 1, got %q", v)
+		}
+		return int(v[0]), nil
+	case byte:
+		return int(v), nil
+	case rune:
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("unhandled type for ascii code %T", arg)
+	}
+}
+
+// splitBasePrefix moves a 0x, 0o or 0b prefix out of s, keeping the sign, and
+// returns the base it stands for.
+func splitBasePrefix(s string) (digits string, base int) {
+	sign := ""
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		sign, s = s[:1], s[1:]
+	}
+
+	// the sign only goes before the prefix, 0x-5 is left for strconv to reject
+	if len(s) > 2 && s[0] == '0' && s[2] != '+' && s[2] != '-' {
+		switch s[1] {
+		case 'x', 'X':
+			return sign + s[2:], 16
+		case 'o', 'O':
+			return sign + s[2:], 8
+		case 'b', 'B':
+			return sign + s[2:], 2
+		}
+	}
+	return sign + s, 10
+}
@@ -4,4 +4,4 @@ go 1.21.3
 
 require golang.org/x/net v0.19.0
 
-require github.com/emirpasic/gods v1.18.1 // indirect
+require github.com/emirpasic/gods v1.18.1
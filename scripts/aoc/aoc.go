@@ -15,9 +15,10 @@ import (
 )
 
 func ParseFlags() (day, year int, cookie string) {
-	today := time.Now()
-	flag.IntVar(&day, "day", today.Day(), "day number to fetch, 1-25")
-	flag.IntVar(&year, "year", today.Year(), "AOC year")
+	// puzzles unlock at midnight US Eastern, not local midnight
+	today, thisYear := Today(time.Now())
+	flag.IntVar(&day, "day", today, "day number to fetch, 1-25")
+	flag.IntVar(&year, "year", thisYear, "AOC year")
 	// defaults to env variable
 	flag.StringVar(&cookie, "cookie", os.Getenv("AOC_SESSION_COOKIE"), "AOC session cookie")
 	flag.Parse()
@@ -0,0 +1,153 @@
+package aoc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// LeaderboardCacheTime is how long a private leaderboard is cached for. The
+// site asks for it to be fetched at most once every 15 minutes.
+const LeaderboardCacheTime = 15 * time.Minute
+
+// Client calls adventofcode.com with a session cookie, returning errors
+// rather than exiting like the fetch helpers.
+type Client struct {
+	BaseURL string
+	Cookie  string
+	HTTP    *http.Client
+	// CacheDir keeps responses that are rate limited, like leaderboards
+	CacheDir string
+
+	now func() time.Time
+}
+
+// NewClient makes a client for the real site, caching in the user's cache dir.
+func NewClient(cookie string) *Client {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return &Client{
+		BaseURL:  "https://adventofcode.com",
+		Cookie:   cookie,
+		HTTP:     &http.Client{Timeout: 10 * time.Second},
+		CacheDir: filepath.Join(cacheDir, "aoc"),
+		now:      time.Now,
+	}
+}
+
+// Leaderboard returns a private leaderboard, from the cache if it was fetched
+// less than LeaderboardCacheTime ago.
+func (c *Client) Leaderboard(ctx context.Context, year int, id string) (*Leaderboard, error) {
+	cacheFile := filepath.Join(c.CacheDir, fmt.Sprintf("leaderboard-%d-%s.json", year, id))
+
+	var lb Leaderboard
+	if body, err := c.cached(cacheFile, LeaderboardCacheTime); err == nil {
+		if err := json.Unmarshal(body, &lb); err == nil {
+			return &lb, nil
+		}
+	}
+
+	body, err := c.get(ctx, fmt.Sprintf("/%d/leaderboard/private/view/%s.json", year, id))
+	if err != nil {
+		return nil, err
+	}
+	// a bad cookie gets the login page, which mustn't be cached
+	lb = Leaderboard{}
+	if err := json.Unmarshal(body, &lb); err != nil {
+		return nil, fmt.Errorf("parsing leaderboard, is the cookie right? %w", err)
+	}
+
+	if err := os.MkdirAll(c.CacheDir, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(cacheFile, body, 0644); err != nil {
+		return nil, err
+	}
+	return &lb, nil
+}
+
+// calendarDay matches a day on the calendar page, which has an aria-label
+// like "Day 5, two stars" once it has stars
+var calendarDay = regexp.MustCompile(`aria-label="Day (\d+)(?:, (one star|two stars))?"`)
+
+// Calendar returns how many stars the user has for each unlocked day of year.
+func (c *Client) Calendar(ctx context.Context, year int) (map[int]int, error) {
+	body, err := c.get(ctx, fmt.Sprintf("/%d", year))
+	if err != nil {
+		return nil, err
+	}
+	return ParseCalendar(body), nil
+}
+
+// ParseCalendar reads the star counts off a /{year} calendar page.
+func ParseCalendar(page []byte) map[int]int {
+	stars := map[int]int{}
+	for _, match := range calendarDay.FindAllSubmatch(page, -1) {
+		day, _ := strconv.Atoi(string(match[1]))
+		switch string(match[2]) {
+		case "one star":
+			stars[day] = 1
+		case "two stars":
+			stars[day] = 2
+		default:
+			stars[day] = 0
+		}
+	}
+	return stars
+}
+
+// cached returns the file's contents if it's younger than maxAge
+func (c *Client) cached(filename string, maxAge time.Duration) ([]byte, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+	if c.clock().Sub(info.ModTime()) >= maxAge {
+		return nil, fmt.Errorf("%s is out of date", filename)
+	}
+	return os.ReadFile(filename)
+}
+
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Cookie})
+	req.Header.Set("User-Agent", "github.com/Kris-Pelteshki/aoc_2023")
+
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", path, res.Status)
+	}
+	return body, nil
+}
+
+func (c *Client) clock() time.Time {
+	if c.now == nil {
+		return time.Now()
+	}
+	return c.now()
+}
@@ -0,0 +1,168 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Leaderboard is the JSON of a private leaderboard.
+type Leaderboard struct {
+	OwnerID int               `json:"owner_id"`
+	Event   string            `json:"event"`
+	Members map[string]Member `json:"members"`
+}
+
+type Member struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Stars      int    `json:"stars"`
+	LocalScore int    `json:"local_score"`
+	LastStarTS int64  `json:"last_star_ts"`
+	// CompletionDayLevel is keyed by day then part, both as strings
+	CompletionDayLevel map[string]map[string]Star `json:"completion_day_level"`
+}
+
+type Star struct {
+	GetStarTS int64 `json:"get_star_ts"`
+	StarIndex int   `json:"star_index"`
+}
+
+// DisplayName is the member's name, anonymous members only have an ID.
+func (m Member) DisplayName() string {
+	if m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+	return m.Name
+}
+
+// StarTime returns when the member got a part's star.
+func (m Member) StarTime(day, part int) (time.Time, bool) {
+	star, ok := m.CompletionDayLevel[strconv.Itoa(day)][strconv.Itoa(part)]
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(star.GetStarTS, 0), true
+}
+
+// Ranking returns the members by local score, then by who got their last star
+// first, which is how the site breaks ties.
+func (lb *Leaderboard) Ranking() []Member {
+	members := make([]Member, 0, len(lb.Members))
+	for _, m := range lb.Members {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		if a.LocalScore != b.LocalScore {
+			return a.LocalScore > b.LocalScore
+		}
+		if a.LastStarTS != b.LastStarTS {
+			return a.LastStarTS < b.LastStarTS
+		}
+		return a.ID < b.ID
+	})
+	return members
+}
+
+// Days returns the days anyone has a star for, in order.
+func (lb *Leaderboard) Days() []int {
+	seen := map[int]bool{}
+	for _, m := range lb.Members {
+		for day := range m.CompletionDayLevel {
+			if d, err := strconv.Atoi(day); err == nil {
+				seen[d] = true
+			}
+		}
+	}
+	days := make([]int, 0, len(seen))
+	for d := range seen {
+		days = append(days, d)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Year is the event year, or 0 if the event isn't a number.
+func (lb *Leaderboard) Year() int {
+	year, _ := strconv.Atoi(lb.Event)
+	return year
+}
+
+// WriteReport writes the ranking, each member's star timeline and the time
+// between their part 1 and part 2 as Markdown tables.
+func (lb *Leaderboard) WriteReport(w io.Writer) error {
+	var sb strings.Builder
+	ranking := lb.Ranking()
+	days := lb.Days()
+
+	fmt.Fprintf(&sb, "# Leaderboard %s\n\n", lb.Event)
+
+	sb.WriteString("## Ranking\n\n")
+	sb.WriteString("| Rank | Member | Local score | Stars |\n")
+	sb.WriteString("| ---: | --- | ---: | ---: |\n")
+	for i, m := range ranking {
+		fmt.Fprintf(&sb, "| %d | %s | %d | %d |\n", i+1, escapeCell(m.DisplayName()), m.LocalScore, m.Stars)
+	}
+
+	sb.WriteString("\n## Star timeline\n\n")
+	sb.WriteString("Time from the puzzle unlocking to each star.\n\n")
+	sb.WriteString("| Member | Day | Part 1 | Part 2 |\n")
+	sb.WriteString("| --- | ---: | ---: | ---: |\n")
+	for _, m := range ranking {
+		for _, day := range days {
+			unlock := UnlockTime(day, lb.Year())
+			part1, ok1 := m.StarTime(day, 1)
+			part2, ok2 := m.StarTime(day, 2)
+			if !ok1 && !ok2 {
+				continue
+			}
+			fmt.Fprintf(&sb, "| %s | %d | %s | %s |\n", escapeCell(m.DisplayName()), day,
+				since(unlock, part1, ok1), since(unlock, part2, ok2))
+		}
+	}
+
+	sb.WriteString("\n## Part 1 to part 2\n\n")
+	sb.WriteString("| Member |")
+	for _, day := range days {
+		fmt.Fprintf(&sb, " %d |", day)
+	}
+	sb.WriteString("\n| --- |" + strings.Repeat(" ---: |", len(days)) + "\n")
+	for _, m := range ranking {
+		fmt.Fprintf(&sb, "| %s |", escapeCell(m.DisplayName()))
+		for _, day := range days {
+			part1, ok1 := m.StarTime(day, 1)
+			part2, ok2 := m.StarTime(day, 2)
+			fmt.Fprintf(&sb, " %s |", since(part1, part2, ok1 && ok2))
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// since formats the time from start to end, or - if there's no end
+func since(start, end time.Time, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return formatDuration(end.Sub(start))
+}
+
+// formatDuration is like 1d 02:03:04, leaving off days when there are none
+func formatDuration(d time.Duration) string {
+	secs := int(d / time.Second)
+	clock := fmt.Sprintf("%02d:%02d:%02d", secs/3600%24, secs/60%60, secs%60)
+	if days := secs / (24 * 3600); days > 0 {
+		return fmt.Sprintf("%dd %s", days, clock)
+	}
+	return clock
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
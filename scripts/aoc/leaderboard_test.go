@@ -0,0 +1,147 @@
+package aoc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFixtureServer serves testdata/leaderboard.json like the site would,
+// counting requests
+func newFixtureServer(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+	fixture, err := os.ReadFile("testdata/leaderboard.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2023/leaderboard/private/view/1.json" {
+			http.NotFound(w, r)
+			return
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			// the site redirects to the login page instead
+			w.Write([]byte("<html>log in</html>"))
+			return
+		}
+		hits++
+		w.Write(fixture)
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func newTestClient(t *testing.T, url string, now *time.Time) *Client {
+	return &Client{
+		BaseURL:  url,
+		Cookie:   "secret",
+		CacheDir: t.TempDir(),
+		now:      func() time.Time { return *now },
+	}
+}
+
+func TestClient_Leaderboard(t *testing.T) {
+	server, hits := newFixtureServer(t)
+	now := time.Now()
+	client := newTestClient(t, server.URL, &now)
+	ctx := context.Background()
+
+	lb, err := client.Leaderboard(ctx, 2023, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lb.Members) != 3 || lb.Members["1"].Name != "Alice" {
+		t.Errorf("Leaderboard() = %+v, want the fixture's 3 members", lb)
+	}
+
+	if _, err := client.Leaderboard(ctx, 2023, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if *hits != 1 {
+		t.Errorf("fetched %d times within the cache time, want 1", *hits)
+	}
+
+	now = now.Add(LeaderboardCacheTime + time.Minute)
+	if _, err := client.Leaderboard(ctx, 2023, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if *hits != 2 {
+		t.Errorf("fetched %d times after the cache expired, want 2", *hits)
+	}
+}
+
+func TestClient_Leaderboard_badCookie(t *testing.T) {
+	server, _ := newFixtureServer(t)
+	now := time.Now()
+	client := newTestClient(t, server.URL, &now)
+	client.Cookie = "wrong"
+
+	if _, err := client.Leaderboard(context.Background(), 2023, "1"); err == nil {
+		t.Fatal("Leaderboard() with a bad cookie should error")
+	}
+
+	// the login page mustn't have been cached
+	client.Cookie = "secret"
+	if _, err := client.Leaderboard(context.Background(), 2023, "1"); err != nil {
+		t.Errorf("Leaderboard() after fixing the cookie = %v", err)
+	}
+}
+
+func TestLeaderboard_WriteReport(t *testing.T) {
+	server, _ := newFixtureServer(t)
+	now := time.Now()
+	lb, err := newTestClient(t, server.URL, &now).Leaderboard(context.Background(), 2023, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+	if err := lb.WriteReport(&sb); err != nil {
+		t.Fatal(err)
+	}
+	report := sb.String()
+
+	wantLines := []string{
+		"| 1 | Alice | 11 | 3 |",
+		`| 2 | Bob \| B | 9 | 2 |`,
+		"| 3 | (anonymous user #3) | 0 | 0 |",
+		// timeline from unlock
+		"| Alice | 1 | 00:10:00 | 00:15:00 |",
+		"| Alice | 2 | 01:00:00 | - |",
+		`| Bob \| B | 1 | 00:20:00 | 1d 01:00:00 |`,
+		// part 1 to part 2 by day
+		"| Member | 1 | 2 |",
+		"| Alice | 00:05:00 | - |",
+		`| Bob \| B | 1d 00:40:00 | - |`,
+		"| (anonymous user #3) | - | - |",
+	}
+	for _, line := range wantLines {
+		if !strings.Contains(report, line+"\n") {
+			t.Errorf("report is missing %q:\n%s", line, report)
+		}
+	}
+}
+
+func TestParseCalendar(t *testing.T) {
+	page, err := os.ReadFile("testdata/calendar.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := ParseCalendar(page)
+	want := map[int]int{1: 2, 2: 1, 3: 0}
+	if len(got) != len(want) {
+		t.Fatalf("ParseCalendar() = %v, want %v", got, want)
+	}
+	for day, stars := range want {
+		if got[day] != stars {
+			t.Errorf("ParseCalendar()[%d] = %d, want %d", day, got[day], stars)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+)
+
+// Eastern is the timezone puzzles unlock in, at midnight each day. December is
+// always EST so a fixed zone is right if the tz database isn't installed.
+var Eastern = loadEastern()
+
+func loadEastern() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}
+
+// Today returns the day and year of the latest puzzle date at now, which is
+// the date in US Eastern rather than the local one.
+func Today(now time.Time) (day, year int) {
+	eastern := now.In(Eastern)
+	return eastern.Day(), eastern.Year()
+}
+
+// NextUnlock returns the next puzzle to unlock after now, which is day 1 of
+// this year's event before December. After day 25 it's next year's day 1.
+func NextUnlock(now time.Time) (day, year int) {
+	eastern := now.In(Eastern)
+	if eastern.Month() == time.December && eastern.Day() < 25 {
+		return eastern.Day() + 1, eastern.Year()
+	}
+	if eastern.Month() == time.December {
+		return 1, eastern.Year() + 1
+	}
+	return 1, eastern.Year()
+}
+
+// UnlockTime is when a day's puzzle and input become available.
+func UnlockTime(day, year int) time.Time {
+	return time.Date(year, time.December, day, 0, 0, 0, 0, Eastern)
+}
+
+// WaitForUnlock blocks until day's puzzle unlocks, printing a countdown to out,
+// then waits a random extra time up to jitter so everyone's requests don't
+// land on the same second.
+func WaitForUnlock(ctx context.Context, day, year int, jitter time.Duration, out io.Writer) error {
+	unlock := UnlockTime(day, year)
+	fmt.Fprintf(out, "day %d unlocks at %s (%s local)\n", day, unlock.Format(time.Kitchen+" MST"),
+		unlock.Local().Format("Jan 2 "+time.Kitchen))
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		left := time.Until(unlock)
+		if left <= 0 {
+			break
+		}
+		fmt.Fprintf(out, "\r%s ", Countdown(left))
+
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(out)
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	fmt.Fprintln(out, "\runlocked!        ")
+
+	if jitter <= 0 {
+		return nil
+	}
+	delay := time.Duration(rand.Int63n(int64(jitter)))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// Countdown formats the time left as hh:mm:ss, rounded up to the second.
+func Countdown(left time.Duration) string {
+	secs := int((left + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+}
@@ -0,0 +1,75 @@
+package aoc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToday(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      time.Time
+		wantDay  int
+		wantYear int
+	}{
+		{"just after unlock", time.Date(2023, 12, 5, 5, 0, 1, 0, time.UTC), 5, 2023},
+		{"just before unlock", time.Date(2023, 12, 5, 4, 59, 59, 0, time.UTC), 4, 2023},
+		{"next day in tokyo", time.Date(2023, 12, 6, 10, 0, 0, 0, time.FixedZone("JST", 9*60*60)), 5, 2023},
+		{"new year in utc", time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC), 31, 2023},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, year := Today(tt.now)
+			if day != tt.wantDay || year != tt.wantYear {
+				t.Errorf("Today() = %d, %d, want %d, %d", day, year, tt.wantDay, tt.wantYear)
+			}
+		})
+	}
+}
+
+func TestNextUnlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      time.Time
+		wantDay  int
+		wantYear int
+	}{
+		{"november", time.Date(2023, 11, 30, 12, 0, 0, 0, Eastern), 1, 2023},
+		{"during the event", time.Date(2023, 12, 5, 23, 59, 0, 0, Eastern), 6, 2023},
+		{"right at unlock", time.Date(2023, 12, 6, 0, 0, 0, 0, Eastern), 7, 2023},
+		{"after day 25", time.Date(2023, 12, 25, 1, 0, 0, 0, Eastern), 1, 2024},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, year := NextUnlock(tt.now)
+			if day != tt.wantDay || year != tt.wantYear {
+				t.Errorf("NextUnlock() = %d, %d, want %d, %d", day, year, tt.wantDay, tt.wantYear)
+			}
+		})
+	}
+}
+
+func TestUnlockTime(t *testing.T) {
+	got := UnlockTime(1, 2023).UTC()
+	want := time.Date(2023, 12, 1, 5, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("UnlockTime() = %s, want %s", got, want)
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	tests := []struct {
+		left time.Duration
+		want string
+	}{
+		{0, "00:00:00"},
+		{500 * time.Millisecond, "00:00:01"},
+		{time.Minute + 2*time.Second, "00:01:02"},
+		{25*time.Hour + 30*time.Minute, "25:30:00"},
+	}
+	for _, tt := range tests {
+		if got := Countdown(tt.left); got != tt.want {
+			t.Errorf("Countdown(%s) = %q, want %q", tt.left, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+)
+
+// bench runs every finished part many times on its input and records the
+// mean times for the README, with -profile collecting CPU and memory
+// profiles across all the runs instead
+func bench(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	year := flags.Int("year", 0, "only bench this year")
+	day := flags.Int("day", 0, "only bench this day")
+	part := flags.Int("part", 0, "only bench this part")
+	runs := flags.Int("n", 10, "how many times to run each part")
+	profile := flags.Bool("profile", false, "profile each part's runs and print its hottest functions")
+	top := flags.Int("top", 10, "how many hot functions to print from each profile")
+	flags.Parse(args)
+
+	root := days.Root()
+	found, err := days.Discover(root)
+	if err != nil {
+		return err
+	}
+	store, err := records.Open(filepath.Join(root, records.DefaultPath))
+	if err != nil {
+		return err
+	}
+
+	runFlags := []string{"-bench", fmt.Sprint(*runs), "-top", fmt.Sprint(*top)}
+	if *profile {
+		runFlags = append(runFlags, "-cpuprofile", "-memprofile")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "day\tpart\tanswer\tmean time")
+	for _, d := range found {
+		if (*year != 0 && d.Year != *year) || (*day != 0 && d.Day != *day) {
+			continue
+		}
+		for p := 1; p <= 2; p++ {
+			if (*part != 0 && p != *part) || !d.Implemented(p) {
+				continue
+			}
+			fmt.Printf("== %s part %d\n", d, p)
+			res, err := d.Run(ctx, p, os.Stdout, runFlags...)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%v\n", d, p, res.Answer, res.Duration)
+			// profiling slows the part down too much to record
+			if *profile {
+				continue
+			}
+			err = store.Add(records.Run{
+				Year: d.Year, Day: d.Day, Part: p,
+				Answer: res.Answer, Duration: res.Duration, Runs: *runs,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	fmt.Println()
+	return tw.Flush()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+)
+
+// check runs every day's parts against its named inputs and reports which
+// inputs each part fails on
+func check(args []string) error {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	year := flags.Int("year", 0, "only check this year")
+	day := flags.Int("day", 0, "only check this day")
+	flags.Parse(args)
+
+	found, err := days.Discover(days.Root())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "day\tpart\tinput\tresult")
+	failed := 0
+	for _, d := range found {
+		if (*year != 0 && d.Year != *year) || (*day != 0 && d.Day != *day) || !d.Implemented(1) {
+			continue
+		}
+		results, err := d.Test(ctx, "^Test_inputs$")
+		if err != nil {
+			return err
+		}
+
+		for _, res := range results {
+			// only the leaves, like Test_inputs/part1/alice
+			parts := strings.Split(res.Name, "/")
+			if len(parts) != 3 {
+				continue
+			}
+			result := "pass"
+			switch {
+			case res.Skipped:
+				result = "skip"
+			case !res.Passed:
+				result = "FAIL"
+				failed++
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d, parts[1], parts[2], result)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d part(s) failed on a named input", failed)
+	}
+	return nil
+}
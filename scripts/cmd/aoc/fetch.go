@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/aoc"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/skeleton"
+)
+
+func fetch(args []string) error {
+	today, thisYear := aoc.Today(time.Now())
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	day := flags.Int("day", today, "day number to fetch, 1-25")
+	year := flags.Int("year", thisYear, "AOC year")
+	cookie := flags.String("cookie", os.Getenv("AOC_SESSION_COOKIE"), "AOC session cookie")
+	wait := flags.Bool("wait", false, "wait for the puzzle to unlock, -day defaults to the next one")
+	jitter := flags.Duration("jitter", 5*time.Second, "random extra wait after unlock with -wait")
+	flags.Parse(args)
+
+	if *wait && !isSet(flags, "day") {
+		*day, *year = aoc.NextUnlock(time.Now())
+	}
+	if *day > 25 || *day < 1 {
+		return fmt.Errorf("day out of range: %d", *day)
+	}
+	if *year < 2015 {
+		return fmt.Errorf("year is before 2015: %d", *year)
+	}
+	if *cookie == "" {
+		return fmt.Errorf("no session cookie set on flag or env var (AOC_SESSION_COOKIE)")
+	}
+
+	if *wait {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if err := aoc.WaitForUnlock(ctx, *day, *year, *jitter, os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	aoc.GetInput(*day, *year, *cookie)
+	aoc.GetPrompt(*day, *year, *cookie)
+
+	mainFile := filepath.Join(days.Root(), fmt.Sprint(*year), fmt.Sprintf("day%02d", *day), "main.go")
+	if _, err := os.Stat(mainFile); err == nil {
+		fmt.Println("skeleton already exists:", mainFile)
+		return nil
+	}
+	skeleton.Run(*day, *year)
+	return nil
+}
+
+func isSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
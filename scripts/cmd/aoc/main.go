@@ -0,0 +1,67 @@
+// Command aoc collects the repo's tools as subcommands:
+//
+//	aoc serve [-addr localhost:8023]
+//	aoc fetch [-day N] [-year N] [-wait] [-jitter 5s]
+//	aoc report [-year N] [-id ID]
+//	aoc status [-tests=false] [-calendar]
+//	aoc accept [-day N] [-year N] [-part N]
+//	aoc readme [-calendar]
+//	aoc vault lock|unlock
+//	aoc check [-year N] [-day N]
+//	aoc run [-year N] [-day N] [-part N] [-timeout 1m]
+//	aoc bench [-year N] [-day N] [-part N] [-n 10] [-profile] [-top 10]
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"serve", "serve a local dashboard of every day", serve},
+	{"fetch", "fetch a day's input and prompt and make its skeleton", fetch},
+	{"report", "report on a private leaderboard as Markdown", report},
+	{"status", "show every year's progress as a grid", status},
+	{"accept", "mark a part's last recorded answer as accepted", accept},
+	{"readme", "regenerate the progress tables in README.md", readmeCmd},
+	{"vault", "encrypt inputs to commit them, or decrypt them", vaultCmd},
+	{"check", "run parts on every named input in the days' inputs/", check},
+	{"run", "run every finished part, reporting the ones that time out", run},
+	{"bench", "time parts over many runs, and profile them", bench},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "aoc %s: %s\n", cmd.name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: aoc <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+}
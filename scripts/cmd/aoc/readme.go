@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/aoc"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/progress"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/readme"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+)
+
+func readmeCmd(args []string) error {
+	flags := flag.NewFlagSet("readme", flag.ExitOnError)
+	calendar := flags.Bool("calendar", false, "take stars from each year's calendar, needs a cookie")
+	cookie := flags.String("cookie", os.Getenv("AOC_SESSION_COOKIE"), "AOC session cookie")
+	flags.Parse(args)
+
+	root := days.Root()
+	store, err := records.Open(filepath.Join(root, records.DefaultPath))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var opts progress.Options
+	if *calendar {
+		if *cookie == "" {
+			return errors.New("-calendar needs a session cookie on flag or env var (AOC_SESSION_COOKIE)")
+		}
+		opts.Calendars, err = fetchCalendars(ctx, root, aoc.NewClient(*cookie))
+		if err != nil {
+			return err
+		}
+	}
+
+	found, err := progress.Collect(ctx, root, store, opts)
+	if err != nil {
+		return err
+	}
+	return readme.Update(filepath.Join(root, "README.md"), root, found, store)
+}
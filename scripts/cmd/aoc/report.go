@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/aoc"
+)
+
+func report(args []string) error {
+	_, thisYear := aoc.Today(time.Now())
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	year := flags.Int("year", thisYear, "AOC year")
+	id := flags.String("id", os.Getenv("AOC_LEADERBOARD_ID"), "private leaderboard id, defaults to $AOC_LEADERBOARD_ID")
+	cookie := flags.String("cookie", os.Getenv("AOC_SESSION_COOKIE"), "AOC session cookie")
+	flags.Parse(args)
+
+	if *id == "" {
+		return errors.New("no leaderboard id set on flag or env var (AOC_LEADERBOARD_ID)")
+	}
+	if *cookie == "" {
+		return errors.New("no session cookie set on flag or env var (AOC_SESSION_COOKIE)")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	lb, err := aoc.NewClient(*cookie).Leaderboard(ctx, *year, *id)
+	if err != nil {
+		return err
+	}
+	return lb.WriteReport(os.Stdout)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+)
+
+// run runs every finished part of a year on its input, giving up on each
+// after a timeout and carrying on with the rest, then reports the parts
+// that timed out or failed. Answers are recorded for aoc accept.
+func run(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	year := flags.Int("year", 0, "only run this year")
+	day := flags.Int("day", 0, "only run this day")
+	part := flags.Int("part", 0, "only run this part")
+	timeout := flags.Duration("timeout", time.Minute, "give up on a part after this long")
+	flags.Parse(args)
+
+	root := days.Root()
+	found, err := days.Discover(root)
+	if err != nil {
+		return err
+	}
+	store, err := records.Open(filepath.Join(root, records.DefaultPath))
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "day\tpart\tanswer\ttime")
+	var timedOut, failed []string
+	for _, d := range found {
+		if (*year != 0 && d.Year != *year) || (*day != 0 && d.Day != *day) {
+			continue
+		}
+		for p := 1; p <= 2; p++ {
+			if (*part != 0 && p != *part) || !d.Implemented(p) {
+				continue
+			}
+			name := fmt.Sprintf("%s part %d", d, p)
+
+			var out bytes.Buffer
+			res, err := d.Run(context.Background(), p, &out, "-timeout", timeout.String())
+			switch {
+			case errors.Is(err, runner.ErrTimeout):
+				timedOut = append(timedOut, name)
+				fmt.Fprintf(tw, "%s\t%d\t\ttimed out\n", d, p)
+			case err != nil:
+				failed = append(failed, name)
+				fmt.Fprintf(tw, "%s\t%d\t\tFAIL\n", d, p)
+				fmt.Fprintf(os.Stderr, "%s\n%s", err, out.String())
+			default:
+				fmt.Fprintf(tw, "%s\t%d\t%s\t%v\n", d, p, res.Answer, res.Duration)
+				err = store.Add(records.Run{
+					Year: d.Year, Day: d.Day, Part: p,
+					Answer: res.Answer, Duration: res.Duration,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var problems []string
+	if len(timedOut) > 0 {
+		problems = append(problems, fmt.Sprintf("timed out after %v: %s", *timeout, strings.Join(timedOut, ", ")))
+	}
+	if len(failed) > 0 {
+		problems = append(problems, "failed: "+strings.Join(failed, ", "))
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"path/filepath"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/dashboard"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+)
+
+func serve(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	// localhost only by default, the dashboard runs code
+	addr := flags.String("addr", "localhost:8023", "address to listen on")
+	flags.Parse(args)
+
+	root := days.Root()
+	store, err := records.Open(filepath.Join(root, records.DefaultPath))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("dashboard on http://%s", *addr)
+	return http.ListenAndServe(*addr, dashboard.New(root, *addr, store))
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/aoc"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/progress"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+)
+
+func status(args []string) error {
+	flags := flag.NewFlagSet("status", flag.ExitOnError)
+	tests := flags.Bool("tests", true, "run the example tests of implemented days")
+	calendar := flags.Bool("calendar", false, "fetch star counts from each year's calendar, needs a cookie")
+	cookie := flags.String("cookie", os.Getenv("AOC_SESSION_COOKIE"), "AOC session cookie")
+	flags.Parse(args)
+
+	root := days.Root()
+	store, err := records.Open(filepath.Join(root, records.DefaultPath))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	opts := progress.Options{Tests: *tests}
+	if *calendar {
+		if *cookie == "" {
+			return errors.New("-calendar needs a session cookie on flag or env var (AOC_SESSION_COOKIE)")
+		}
+		opts.Calendars, err = fetchCalendars(ctx, root, aoc.NewClient(*cookie))
+		if err != nil {
+			return err
+		}
+	}
+
+	found, err := progress.Collect(ctx, root, store, opts)
+	if err != nil {
+		return err
+	}
+	if err := progress.WriteGrid(os.Stdout, found, opts); err != nil {
+		return err
+	}
+
+	var broken []string
+	for _, d := range found {
+		if d.TestErr != nil {
+			broken = append(broken, d.Day.String())
+			fmt.Fprintf(os.Stderr, "\n%s: %v\n", d.Day, d.TestErr)
+		}
+	}
+	if len(broken) > 0 {
+		return fmt.Errorf("couldn't run the examples of %s", strings.Join(broken, ", "))
+	}
+	return nil
+}
+
+// fetchCalendars gets the calendar of every year that has days in the repo
+func fetchCalendars(ctx context.Context, root string, client *aoc.Client) (map[int]map[int]int, error) {
+	found, err := days.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+
+	calendars := map[int]map[int]int{}
+	for _, d := range found {
+		if _, ok := calendars[d.Year]; ok {
+			continue
+		}
+		calendars[d.Year], err = client.Calendar(ctx, d.Year)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %d calendar: %w", d.Year, err)
+		}
+	}
+	return calendars, nil
+}
+
+func accept(args []string) error {
+	today, thisYear := aoc.Today(time.Now())
+	flags := flag.NewFlagSet("accept", flag.ExitOnError)
+	day := flags.Int("day", today, "day number, 1-25")
+	year := flags.Int("year", thisYear, "AOC year")
+	part := flags.Int("part", 1, "part 1 or 2")
+	flags.Parse(args)
+
+	store, err := records.Open(filepath.Join(days.Root(), records.DefaultPath))
+	if err != nil {
+		return err
+	}
+	run, err := store.Accept(*year, *day, *part)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("accepted %d day %02d part %d: %s\n", run.Year, run.Day, run.Part, run.Answer)
+	return nil
+}
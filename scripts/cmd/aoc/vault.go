@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
+)
+
+func vaultCmd(args []string) error {
+	flags := flag.NewFlagSet("vault", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: aoc vault lock|unlock")
+		fmt.Fprintln(os.Stderr, "  lock encrypts every input.txt and inputs/*.txt to .enc files to be committed")
+		fmt.Fprintln(os.Stderr, "  unlock writes them back from the .enc files")
+	}
+	flags.Parse(args)
+
+	passphrase := os.Getenv(vault.KeyEnv)
+	if passphrase == "" {
+		return errors.New("no passphrase set on env var " + vault.KeyEnv)
+	}
+
+	found, err := days.Discover(days.Root())
+	if err != nil {
+		return err
+	}
+
+	switch flags.Arg(0) {
+	case "lock":
+		for _, d := range found {
+			for _, filename := range inputFiles(d, "") {
+				changed, err := vault.LockFile(filename, passphrase)
+				if err != nil {
+					return fmt.Errorf("%s: %w", d, err)
+				}
+				if changed {
+					fmt.Println("encrypted", relPath(filename+vault.Ext))
+				}
+			}
+		}
+	case "unlock":
+		for _, d := range found {
+			for _, sealed := range inputFiles(d, vault.Ext) {
+				filename := strings.TrimSuffix(sealed, vault.Ext)
+				if err := vault.UnlockFile(filename, passphrase); err != nil {
+					return err
+				}
+				fmt.Println("decrypted", relPath(filename))
+			}
+		}
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+	return nil
+}
+
+// inputFiles finds a day's input.txt and named inputs, with ext added.
+// Generated inputs are committed as they are.
+func inputFiles(d days.Day, ext string) []string {
+	var files []string
+	if _, err := os.Stat(d.File("input.txt" + ext)); err == nil {
+		files = append(files, d.File("input.txt"+ext))
+	}
+	named, _ := filepath.Glob(filepath.Join(d.Dir, inputs.Dir, "*.txt"+ext))
+	for _, file := range named {
+		if !strings.HasPrefix(filepath.Base(file), "generated-") {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func relPath(filename string) string {
+	rel, err := filepath.Rel(days.Root(), filename)
+	if err != nil {
+		return filename
+	}
+	return rel
+}
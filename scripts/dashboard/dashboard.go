@@ -0,0 +1,254 @@
+// Package dashboard serves a local web page of every day's progress, test
+// results and timings, and runs parts from the browser.
+package dashboard
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"round": func(d time.Duration) time.Duration { return d.Round(time.Microsecond) },
+	"add":   func(a, b int) int { return a + b },
+}).ParseFS(templateFS, "templates/*.html"))
+
+// Server is the dashboard's http.Handler.
+type Server struct {
+	root  string
+	addr  string
+	store *records.Store
+	mux   *http.ServeMux
+}
+
+// New makes a dashboard for the repo at root, recording runs in store. addr
+// is where it listens, and requests for any other host are turned away.
+func New(root, addr string, store *records.Store) *Server {
+	s := &Server{root: root, addr: addr, store: store, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/", s.index)
+	s.mux.HandleFunc("/day", s.day)
+	s.mux.HandleFunc("/run", s.run)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// a site whose name is pointed at 127.0.0.1 after its page has loaded
+	// could reach the dashboard as itself, DNS rebinding, but its name is
+	// still in the Host header
+	if !s.knownHost(r.Host) {
+		http.Error(w, "unknown host "+r.Host, http.StatusForbidden)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// knownHost reports whether host, from a request's Host header, is the
+// address the dashboard listens on, or another name for it on this machine
+func (s *Server) knownHost(host string) bool {
+	if host == s.addr {
+		return true
+	}
+	name, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return false
+	}
+	_, listenPort, err := net.SplitHostPort(s.addr)
+	if err != nil || port != listenPort {
+		return false
+	}
+	if name == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(name)
+	return ip != nil && ip.IsLoopback()
+}
+
+// dayRow is a day with its latest runs. The day isn't embedded since its
+// Day field would be shadowed by the embedded struct in templates.
+type dayRow struct {
+	Day  days.Day
+	Runs [2]*records.Run
+}
+
+func (s *Server) index(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	all, err := days.Discover(s.root)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	years := map[int][]dayRow{}
+	var order []int
+	for _, d := range all {
+		if _, ok := years[d.Year]; !ok {
+			order = append(order, d.Year)
+		}
+		years[d.Year] = append(years[d.Year], s.row(d))
+	}
+
+	s.render(w, "index.html", map[string]any{"Years": order, "Days": years})
+}
+
+func (s *Server) row(d days.Day) dayRow {
+	row := dayRow{Day: d}
+	for part := 1; part <= 2; part++ {
+		if run, ok := s.store.Latest(d.Year, d.Day, part); ok {
+			row.Runs[part-1] = &run
+		}
+	}
+	return row
+}
+
+// day shows a day's page, and runs its tests first for a POST
+func (s *Server) day(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "use GET, or POST to run the tests", http.StatusMethodNotAllowed)
+		return
+	}
+	// tests run code just like runs do
+	if r.Method == http.MethodPost && !sameOrigin(r) {
+		http.Error(w, "tests have to be run from the dashboard", http.StatusForbidden)
+		return
+	}
+	d, ok := s.findDay(w, r)
+	if !ok {
+		return
+	}
+
+	data := map[string]any{"Row": s.row(d)}
+	data["Code"] = readOr(d.File("main.go"), "")
+	data["Prompt"] = markdown(readOr(d.File("prompt.md"), ""))
+
+	if r.Method == http.MethodPost {
+		results, err := d.Test(r.Context(), "")
+		data["Tests"], data["TestErr"] = results, err
+	}
+
+	s.render(w, "day.html", data)
+}
+
+// run streams a part's output as plain text while it runs
+func (s *Server) run(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	// any site open in the browser could POST here, and running code is
+	// worth guarding even on localhost
+	if !sameOrigin(r) {
+		http.Error(w, "runs have to come from the dashboard", http.StatusForbidden)
+		return
+	}
+	d, ok := s.findDay(w, r)
+	if !ok {
+		return
+	}
+	part, err := strconv.Atoi(r.URL.Query().Get("part"))
+	if err != nil || part < 1 || part > 2 {
+		http.Error(w, "part must be 1 or 2", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	out := &flushWriter{w: w}
+	out.flusher, _ = w.(http.Flusher)
+
+	res, err := d.Run(r.Context(), part, out)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return
+	}
+	err = s.store.Add(records.Run{
+		Year: d.Year, Day: d.Day, Part: part,
+		Answer: res.Answer, Duration: res.Duration,
+	})
+	if err != nil {
+		fmt.Fprintln(out, "recording run:", err)
+	}
+}
+
+// sameOrigin reports whether r came from one of the dashboard's own pages.
+// Browsers send Sec-Fetch-Site, or at least Origin, with every POST, so a
+// request with neither is from a tool like curl and is let through.
+func sameOrigin(r *http.Request) bool {
+	if site := r.Header.Get("Sec-Fetch-Site"); site != "" {
+		return site == "same-origin"
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// findDay loads the day in the year and day query params, writing an error
+// response if it can't
+func (s *Server) findDay(w http.ResponseWriter, r *http.Request) (days.Day, bool) {
+	year, yearErr := strconv.Atoi(r.URL.Query().Get("year"))
+	day, dayErr := strconv.Atoi(r.URL.Query().Get("day"))
+	if yearErr != nil || dayErr != nil {
+		http.Error(w, "year and day are required", http.StatusBadRequest)
+		return days.Day{}, false
+	}
+
+	d, err := days.Load(filepath.Join(s.root, fmt.Sprint(year), fmt.Sprintf("day%02d", day)))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("no %d day %02d", year, day), http.StatusNotFound)
+		return days.Day{}, false
+	}
+	return d, true
+}
+
+func (s *Server) render(w http.ResponseWriter, name string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.ExecuteTemplate(w, name, data); err != nil {
+		log.Printf("rendering %s: %s", name, err)
+	}
+}
+
+func readOr(filename, fallback string) string {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fallback
+	}
+	return string(data)
+}
+
+// flushWriter sends every write to the browser straight away. The part's
+// stdout and stderr are copied from different goroutines, so writes lock.
+type flushWriter struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, err := f.w.Write(p)
+	if f.flusher != nil {
+		f.flusher.Flush()
+	}
+	return n, err
+}
@@ -0,0 +1,145 @@
+package dashboard
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "2023", "day01")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc part1(input string) int {\n\treturn 1\n}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "prompt.md"), []byte("--- Day 1: Trebuchet?! ---"), 0644)
+
+	store, err := records.Open(filepath.Join(root, records.DefaultPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Add(records.Run{Year: 2023, Day: 1, Part: 1, Answer: "142"}); err != nil {
+		t.Fatal(err)
+	}
+	return New(root, "example.com", store)
+}
+
+func TestServer(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		wantCode int
+		wantBody string
+	}{
+		{"index", "GET", "/", 200, "142 in 0s"},
+		{"index links days", "GET", "/", 200, `<a href="/day?year=2023&day=1">01</a>`},
+		{"day", "GET", "/day?year=2023&day=1", 200, "<h2>Day 1: Trebuchet?!</h2>"},
+		{"day heading", "GET", "/day?year=2023&day=1", 200, `<h1>2023 day 01 <span`},
+		{"day posts tests", "GET", "/day?year=2023&day=1", 200, `<form method="post" action="/day?year=2023&day=1">`},
+		{"tests don't run on get", "GET", "/day?year=2023&day=1&tests=1", 200, "<button>run tests</button>"},
+		{"day needs get or post", "PUT", "/day?year=2023&day=1", 405, "POST"},
+		{"day runs", "GET", "/day?year=2023&day=1", 200, `fetch("/run?year=2023&day=1&part="`},
+		{"missing day", "GET", "/day?year=2023&day=2", 404, "no 2023 day 02"},
+		{"bad query", "GET", "/day?year=x", 400, "required"},
+		{"run needs post", "GET", "/run?year=2023&day=1&part=1", 405, "POST"},
+		{"bad part", "POST", "/run?year=2023&day=1&part=3", 400, "part must be"},
+		{"unknown path", "GET", "/nope", 404, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body doesn't contain %q:\n%s", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_crossOrigin(t *testing.T) {
+	s := newTestServer(t)
+
+	// part 3 is turned away after the origin check, so allowed requests
+	// don't start a run
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		wantCode int
+	}{
+		{"no headers", nil, 400},
+		{"same site fetch", map[string]string{"Sec-Fetch-Site": "same-origin", "Origin": "http://example.com"}, 400},
+		{"cross site fetch", map[string]string{"Sec-Fetch-Site": "cross-site", "Origin": "http://evil.test"}, 403},
+		{"same site sibling", map[string]string{"Sec-Fetch-Site": "same-site"}, 403},
+		{"same origin", map[string]string{"Origin": "http://example.com"}, 400},
+		{"other origin", map[string]string{"Origin": "http://evil.test"}, 403},
+		{"other port", map[string]string{"Origin": "http://example.com:8080"}, 403},
+		{"opaque origin", map[string]string{"Origin": "null"}, 403},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/run?year=2023&day=1&part=3", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_crossOriginTests(t *testing.T) {
+	s := newTestServer(t)
+	req := httptest.NewRequest("POST", "/day?year=2023&day=1", nil)
+	req.Header.Set("Sec-Fetch-Site", "cross-site")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != 403 {
+		t.Errorf("status = %d, want 403: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestServer_host(t *testing.T) {
+	s := New(t.TempDir(), "localhost:8023", nil)
+
+	tests := []struct {
+		host     string
+		wantCode int
+	}{
+		{"localhost:8023", 400},
+		{"127.0.0.1:8023", 400},
+		{"[::1]:8023", 400},
+		{"evil.test:8023", 403},
+		{"localhost:8080", 403},
+		{"localhost", 403},
+		{"", 403},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			// missing the year, so known hosts get a 400 without a lookup
+			req := httptest.NewRequest("GET", "/day", nil)
+			req.Host = tt.host
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+}
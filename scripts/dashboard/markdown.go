@@ -0,0 +1,111 @@
+package dashboard
+
+import (
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	listItemPattern = regexp.MustCompile(`^(?:([-*+])|\d+[.)])\s+(.*)$`)
+	linkPattern     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	strongPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	emPattern       = regexp.MustCompile(`\*([^*]+)\*`)
+)
+
+// markdown renders the markdown prompts are written in: headings, AoC's
+// --- Day 1: Title --- lines, paragraphs, lists, fenced code blocks, inline
+// code, emphasis and links. The text is escaped before any tags are added,
+// so HTML in a prompt shows as it's written.
+func markdown(src string) template.HTML {
+	var sb strings.Builder
+	var paragraph []string
+	list := ""
+	endParagraph := func() {
+		if len(paragraph) > 0 {
+			sb.WriteString("<p>" + inline(strings.Join(paragraph, "\n")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	endList := func() {
+		if list != "" {
+			sb.WriteString("</" + list + ">\n")
+			list = ""
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case strings.HasPrefix(line, "```"):
+			endParagraph()
+			endList()
+			sb.WriteString("<pre><code>")
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				sb.WriteString(template.HTMLEscapeString(lines[i]) + "\n")
+			}
+			sb.WriteString("</code></pre>\n")
+		case line == "":
+			endParagraph()
+			endList()
+		case headingPattern.MatchString(line):
+			endParagraph()
+			endList()
+			m := headingPattern.FindStringSubmatch(line)
+			tag := "h" + string(rune('0'+len(m[1])))
+			sb.WriteString("<" + tag + ">" + inline(m[2]) + "</" + tag + ">\n")
+		case len(line) > 6 && strings.HasPrefix(line, "--- ") && strings.HasSuffix(line, " ---"):
+			endParagraph()
+			endList()
+			sb.WriteString("<h2>" + inline(strings.Trim(line, "- ")) + "</h2>\n")
+		case listItemPattern.MatchString(line):
+			endParagraph()
+			m := listItemPattern.FindStringSubmatch(line)
+			kind := "ol"
+			if m[1] != "" {
+				kind = "ul"
+			}
+			if list != kind {
+				endList()
+				list = kind
+				sb.WriteString("<" + kind + ">\n")
+			}
+			sb.WriteString("<li>" + inline(m[2]) + "</li>\n")
+		default:
+			endList()
+			paragraph = append(paragraph, line)
+		}
+	}
+	endParagraph()
+	endList()
+	return template.HTML(sb.String())
+}
+
+// inline escapes text and renders its code spans, links and emphasis. Code
+// spans are left as they are inside.
+func inline(text string) string {
+	parts := strings.Split(template.HTMLEscapeString(text), "`")
+	for i, part := range parts {
+		if i%2 == 1 && i < len(parts)-1 {
+			parts[i] = "<code>" + part + "</code>"
+			continue
+		}
+		if i%2 == 1 {
+			// an unclosed backtick stays as it is
+			part = "`" + part
+		}
+		part = linkPattern.ReplaceAllStringFunc(part, func(link string) string {
+			m := linkPattern.FindStringSubmatch(link)
+			if !strings.HasPrefix(m[2], "https://") && !strings.HasPrefix(m[2], "http://") && !strings.HasPrefix(m[2], "/") {
+				// no javascript: or data: links
+				return link
+			}
+			return `<a href="` + m[2] + `">` + m[1] + `</a>`
+		})
+		part = strongPattern.ReplaceAllString(part, "<strong>$1</strong>")
+		parts[i] = emPattern.ReplaceAllString(part, "<em>$1</em>")
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,30 @@
+package dashboard
+
+import "testing"
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"aoc title", "--- Day 1: Trebuchet?! ---", "<h2>Day 1: Trebuchet?!</h2>\n"},
+		{"heading", "## Part Two", "<h2>Part Two</h2>\n"},
+		{"paragraphs", "one\ntwo\n\nthree", "<p>one\ntwo</p>\n<p>three</p>\n"},
+		{"inline", "a *star*, **very** `x < y` [AoC](https://adventofcode.com)",
+			`<p>a <em>star</em>, <strong>very</strong> <code>x &lt; y</code> <a href="https://adventofcode.com">AoC</a></p>` + "\n"},
+		{"code isn't emphasised", "`a*b*c`", "<p><code>a*b*c</code></p>\n"},
+		{"unclosed backtick", "a ` b", "<p>a ` b</p>\n"},
+		{"lists", "- a\n- b\n1. c", "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n<ol>\n<li>c</li>\n</ol>\n"},
+		{"code block", "```\n<b>\n  x\n```\nafter", "<pre><code>&lt;b&gt;\n  x\n</code></pre>\n<p>after</p>\n"},
+		{"html is escaped", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"script links aren't", "[x](javascript:alert(1))", "<p>[x](javascript:alert(1))</p>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(markdown(tt.src)); got != tt.want {
+				t.Errorf("markdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Package days finds the solutions in the repo, works out which are still
+// skeletons and runs their parts and tests.
+package days
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
+)
+
+// Day is one YYYY/dayNN directory.
+type Day struct {
+	Year, Day int
+	Dir       string
+
+	// HasInput is true for a day with an input.txt, or an encrypted
+	// input.txt.enc that might not unlock
+	HasInput  bool
+	HasPrompt bool
+	// Stubs is true for a part whose function still just returns 0
+	Stubs [2]bool
+}
+
+// Root returns the repo root directory.
+func Root() string {
+	return filepath.Join(util.Dirname(), "../..")
+}
+
+// Discover finds every day under root, sorted by year and day.
+func Discover(root string) ([]Day, error) {
+	dirs, err := filepath.Glob(filepath.Join(root, "[0-9][0-9][0-9][0-9]", "day[0-9][0-9]"))
+	if err != nil {
+		return nil, err
+	}
+
+	var days []Day
+	for _, dir := range dirs {
+		day, err := Load(dir)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Year != days[j].Year {
+			return days[i].Year < days[j].Year
+		}
+		return days[i].Day < days[j].Day
+	})
+	return days, nil
+}
+
+// Load reads the day in dir, which has to be named like 2023/day05.
+func Load(dir string) (Day, error) {
+	year, err := strconv.Atoi(filepath.Base(filepath.Dir(dir)))
+	if err != nil {
+		return Day{}, fmt.Errorf("year of %s: %w", dir, err)
+	}
+	dayNum, err := strconv.Atoi(filepath.Base(dir)[len("day"):])
+	if err != nil {
+		return Day{}, fmt.Errorf("day of %s: %w", dir, err)
+	}
+
+	day := Day{
+		Year:      year,
+		Day:       dayNum,
+		Dir:       dir,
+		HasInput:  fileExists(filepath.Join(dir, "input.txt")) || fileExists(filepath.Join(dir, "input.txt"+vault.Ext)),
+		HasPrompt: fileExists(filepath.Join(dir, "prompt.md")),
+	}
+
+	day.Stubs, err = findStubs(filepath.Join(dir, "main.go"))
+	if err != nil {
+		return Day{}, err
+	}
+	return day, nil
+}
+
+// Find returns the day for year and day.
+func Find(days []Day, year, day int) (Day, bool) {
+	for _, d := range days {
+		if d.Year == year && d.Day == day {
+			return d, true
+		}
+	}
+	return Day{}, false
+}
+
+func (d Day) String() string {
+	return fmt.Sprintf("%d day %02d", d.Year, d.Day)
+}
+
+// Implemented reports whether part 1 or 2 has been written.
+func (d Day) Implemented(part int) bool {
+	return !d.Stubs[part-1]
+}
+
+// Status is "skeleton", "part 1" or "done".
+func (d Day) Status() string {
+	switch {
+	case d.Implemented(1) && d.Implemented(2):
+		return "done"
+	case d.Implemented(1):
+		return "part 1"
+	default:
+		return "skeleton"
+	}
+}
+
+func (d Day) File(name string) string {
+	return filepath.Join(d.Dir, name)
+}
+
+// findStubs parses main.go and checks part1 and part2. A missing function
+// counts as a stub.
+func findStubs(filename string) (stubs [2]bool, err error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if err != nil {
+		return stubs, err
+	}
+
+	stubs = [2]bool{true, true}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil {
+			continue
+		}
+		switch fn.Name.Name {
+		case "part1":
+			stubs[0] = isStub(fn.Body)
+		case "part2":
+			stubs[1] = isStub(fn.Body)
+		}
+	}
+	return stubs, nil
+}
+
+// isStub reports whether a part's body is what the skeleton template makes:
+// nothing but parsing the input, discarding it and returning 0.
+func isStub(body *ast.BlockStmt) bool {
+	for _, stmt := range body.List {
+		switch s := stmt.(type) {
+		case *ast.ReturnStmt:
+			if len(s.Results) != 1 {
+				return false
+			}
+			lit, ok := s.Results[0].(*ast.BasicLit)
+			if !ok || lit.Value != "0" {
+				return false
+			}
+		case *ast.AssignStmt:
+			// parsed := cast.Must(parseInput(input)) and _ = parsed
+			for _, rhs := range s.Rhs {
+				switch r := rhs.(type) {
+				case *ast.CallExpr:
+					if !isParseCall(r) {
+						return false
+					}
+				case *ast.Ident:
+				default:
+					return false
+				}
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isParseCall matches parseInput(...), bare or wrapped in cast.Must
+func isParseCall(call *ast.CallExpr) bool {
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Must" && len(call.Args) == 1 {
+		inner, ok := call.Args[0].(*ast.CallExpr)
+		return ok && isParseCall(inner)
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ident.Name == "parseInput"
+}
+
+func fileExists(filename string) bool {
+	_, err := os.Stat(filename)
+	return err == nil
+}
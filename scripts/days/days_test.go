@@ -0,0 +1,131 @@
+package days
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+)
+
+const skeleton = `package main
+
+func part1(input string) int {
+	parsed := cast.Must(parseInput(input))
+	_ = parsed
+
+	return 0
+}
+
+func part2(input string) int {
+	return 0
+}
+`
+
+const halfDone = `package main
+
+func part1(input string) int {
+	total := 0
+	for _, line := range parseInput(input) {
+		total += line
+	}
+	return total
+}
+
+func part2(input string) int {
+	return 0
+}
+`
+
+func writeDay(t *testing.T, root, year, day, code string) string {
+	t.Helper()
+	dir := filepath.Join(root, year, day)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(code), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	writeDay(t, root, "2023", "day02", halfDone)
+	writeDay(t, root, "2023", "day01", skeleton)
+	writeDay(t, root, "2022", "day25", strings.ReplaceAll(halfDone, "return 0", "return 1"))
+	os.WriteFile(filepath.Join(root, "2023", "day01", "input.txt"), []byte("1"), 0644)
+	os.WriteFile(filepath.Join(root, "2022", "day25", "input.txt.enc"), []byte("sealed"), 0644)
+	os.WriteFile(filepath.Join(root, "2023", "day02", "input.txt.placeholder"), nil, 0644)
+
+	days, err := Discover(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		year, day int
+		status    string
+		hasInput  bool
+	}{
+		{2022, 25, "done", true},
+		{2023, 1, "skeleton", true},
+		{2023, 2, "part 1", false},
+	}
+	if len(days) != len(tests) {
+		t.Fatalf("Discover() found %d days, want %d", len(days), len(tests))
+	}
+	for i, tt := range tests {
+		got := days[i]
+		if got.Year != tt.year || got.Day != tt.day || got.Status() != tt.status || got.HasInput != tt.hasInput {
+			t.Errorf("days[%d] = %v %s input=%v, want %d day %02d %s input=%v",
+				i, got, got.Status(), got.HasInput, tt.year, tt.day, tt.status, tt.hasInput)
+		}
+	}
+}
+
+func TestParseOutput(t *testing.T) {
+	got, err := ParseOutput("Running part 1\nOutput: 142\nTime: 1.5ms\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Result{Answer: "142", Duration: 1500 * time.Microsecond}
+	if got != want {
+		t.Errorf("ParseOutput() = %+v, want %+v", got, want)
+	}
+
+	if _, err := ParseOutput("panic: oops\n"); err == nil {
+		t.Error("ParseOutput() without an Output: line should error")
+	}
+	if _, err := ParseOutput("Running part 2\nTimed out after 30s\n"); !errors.Is(err, runner.ErrTimeout) {
+		t.Errorf("ParseOutput() of a timeout = %v, want runner.ErrTimeout", err)
+	}
+}
+
+func TestParseTestEvents(t *testing.T) {
+	events := `{"Action":"run","Test":"Test_part1"}
+{"Action":"output","Test":"Test_part1/example","Output":"ok\n"}
+{"Action":"pass","Test":"Test_part1/example","Elapsed":0.5}
+{"Action":"fail","Test":"Test_part1","Elapsed":1}
+{"Action":"fail","Elapsed":1}
+`
+	got, err := parseTestEvents(strings.NewReader(events))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TestResult{
+		{Name: "Test_part1/example", Passed: true, Elapsed: 500 * time.Millisecond, Output: "ok\n"},
+		{Name: "Test_part1", Elapsed: time.Second},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseTestEvents() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("parseTestEvents()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
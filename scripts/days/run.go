@@ -0,0 +1,147 @@
+package days
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/runner"
+)
+
+// Result is what a part printed when it ran.
+type Result struct {
+	Answer string
+	// Duration is what the part timed itself, without compiling
+	Duration time.Duration
+}
+
+// Run runs a part with go run, copying its output to out as it's printed.
+// Flags like -bench 10 are passed on to the part's runner.
+func (d Day) Run(ctx context.Context, part int, out io.Writer, flags ...string) (Result, error) {
+	var buf bytes.Buffer
+	args := append([]string{"run", ".", "-part", fmt.Sprint(part)}, flags...)
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = d.Dir
+	cmd.Stdout = io.MultiWriter(out, &buf)
+	cmd.Stderr = out
+
+	runErr := cmd.Run()
+	res, err := ParseOutput(buf.String())
+	if errors.Is(err, runner.ErrTimeout) {
+		return res, fmt.Errorf("running %s part %d: %w", d, part, err)
+	}
+	if runErr != nil {
+		return Result{}, fmt.Errorf("running %s part %d: %w", d, part, runErr)
+	}
+	return res, err
+}
+
+// ParseOutput reads the "Output:" and "Time:" lines main prints. A part that
+// timed out prints "Timed out after" instead, which is a runner.ErrTimeout.
+func ParseOutput(output string) (Result, error) {
+	var res Result
+	var found bool
+	for _, line := range strings.Split(output, "\n") {
+		if after, ok := strings.CutPrefix(line, "Timed out after "); ok {
+			return res, fmt.Errorf("%w after %s", runner.ErrTimeout, after)
+		}
+		if answer, ok := strings.CutPrefix(line, "Output: "); ok {
+			res.Answer, found = answer, true
+		}
+		if took, ok := strings.CutPrefix(line, "Time: "); ok {
+			dur, err := time.ParseDuration(took)
+			if err != nil {
+				return res, fmt.Errorf("parsing time: %w", err)
+			}
+			res.Duration = dur
+		}
+	}
+	if !found {
+		return res, fmt.Errorf("no Output: line in %q", output)
+	}
+	return res, nil
+}
+
+// TestResult is one test or subtest, like Test_part1/example.
+type TestResult struct {
+	Name    string
+	Passed  bool
+	Skipped bool
+	Elapsed time.Duration
+	Output  string
+}
+
+// testEvent is a line of go test -json
+type testEvent struct {
+	Action  string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// Test runs the day's tests matching run, or all of them if it's empty, and
+// returns them in the order they finished. A failing test isn't an error, only
+// not being able to run them is.
+func (d Day) Test(ctx context.Context, run string) ([]TestResult, error) {
+	args := []string{"test", "-json", "-count=1"}
+	if run != "" {
+		args = append(args, "-run", run)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", append(args, ".")...)
+	cmd.Dir = d.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// go test exits 1 when a test fails, which still gives json to read
+	runErr := cmd.Run()
+	if stdout.Len() == 0 && runErr != nil {
+		return nil, fmt.Errorf("testing %s: %w: %s", d, runErr, stderr.String())
+	}
+	return parseTestEvents(&stdout)
+}
+
+func parseTestEvents(r io.Reader) ([]TestResult, error) {
+	var results []TestResult
+	outputs := map[string]*strings.Builder{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var event testEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			// build failures are printed as plain text
+			continue
+		}
+		if event.Test == "" {
+			continue
+		}
+
+		switch event.Action {
+		case "output":
+			if outputs[event.Test] == nil {
+				outputs[event.Test] = &strings.Builder{}
+			}
+			outputs[event.Test].WriteString(event.Output)
+		case "pass", "fail", "skip":
+			res := TestResult{
+				Name:    event.Test,
+				Passed:  event.Action == "pass",
+				Skipped: event.Action == "skip",
+				Elapsed: time.Duration(event.Elapsed * float64(time.Second)),
+			}
+			if out := outputs[event.Test]; out != nil {
+				res.Output = out.String()
+			}
+			results = append(results, res)
+		}
+	}
+	return results, scanner.Err()
+}
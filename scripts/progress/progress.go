@@ -0,0 +1,174 @@
+// Package progress combines the days in the repo, the local answer records
+// and the site's calendar into one view of how far each year has got.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+)
+
+// Day is what's known about one day.
+type Day struct {
+	days.Day
+	// Examples is true for an implemented part whose example tests pass
+	Examples [2]bool
+	// TestErr is why the example tests couldn't be run, like a build error
+	TestErr error
+	// Accepted is true for a part with an accepted answer on record
+	Accepted [2]bool
+	// Stars is from the calendar, -1 when it wasn't fetched for the year
+	Stars int
+}
+
+// Options pick the slower sources to include.
+type Options struct {
+	// Tests runs each implemented day's example tests
+	Tests bool
+	// Calendars are star counts by year then day, from aoc.Client.Calendar
+	Calendars map[int]map[int]int
+}
+
+// Collect finds every day under root and fills in what store and opts know
+// about them.
+func Collect(ctx context.Context, root string, store *records.Store, opts Options) ([]Day, error) {
+	found, err := days.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]Day, len(found))
+	for i, d := range found {
+		progress[i] = Day{Day: d, Stars: -1}
+		for part := 1; part <= 2; part++ {
+			_, progress[i].Accepted[part-1] = store.Accepted(d.Year, d.Day, part)
+		}
+		if calendar, ok := opts.Calendars[d.Year]; ok {
+			progress[i].Stars = calendar[d.Day]
+		}
+	}
+
+	if opts.Tests {
+		runExamples(ctx, progress)
+	}
+	return progress, nil
+}
+
+// testWorkers is how many days' tests run at once
+const testWorkers = 4
+
+// runExamples fills in Examples, or TestErr for days whose tests couldn't
+// run, so one broken day doesn't hide the rest
+func runExamples(ctx context.Context, progress []Day) {
+	var wg sync.WaitGroup
+	jobs := make(chan *Day)
+
+	for i := 0; i < testWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range jobs {
+				results, err := d.Test(ctx, "^Test_part[12]$/^example")
+				if err != nil {
+					d.TestErr = err
+					continue
+				}
+				d.Examples = examplesPassed(d.Day, results)
+			}
+		}()
+	}
+
+	for i := range progress {
+		// skeletons can't pass anything. Days build without an input thanks to
+		// input.txt.placeholder, and their examples don't need one.
+		if progress[i].Implemented(1) {
+			jobs <- &progress[i]
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// examplesPassed is true for an implemented part with at least one example
+// subtest where all of them passed
+func examplesPassed(d days.Day, results []days.TestResult) (passed [2]bool) {
+	for part := 1; part <= 2; part++ {
+		if !d.Implemented(part) {
+			continue
+		}
+		prefix := fmt.Sprintf("Test_part%d/example", part)
+		found, failed := false, false
+		for _, res := range results {
+			if strings.HasPrefix(res.Name, prefix) {
+				found = true
+				failed = failed || !res.Passed
+			}
+		}
+		passed[part-1] = found && !failed
+	}
+	return passed
+}
+
+// StarCount is the stars from the calendar, or the accepted answers when the
+// calendar wasn't fetched.
+func (d Day) StarCount() int {
+	if d.Stars >= 0 {
+		return d.Stars
+	}
+	stars := 0
+	for _, accepted := range d.Accepted {
+		if accepted {
+			stars++
+		}
+	}
+	return stars
+}
+
+// WriteGrid writes a table per year of each day's status.
+func WriteGrid(w io.Writer, progress []Day, opts Options) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	for i, d := range progress {
+		if i == 0 || progress[i-1].Year != d.Year {
+			if i > 0 {
+				fmt.Fprintln(tw)
+			}
+			header := []string{fmt.Sprint(d.Year), "status", "input", "prompt"}
+			if opts.Tests {
+				header = append(header, "example 1", "example 2")
+			}
+			header = append(header, "part 1", "part 2", "stars")
+			fmt.Fprintln(tw, strings.Join(header, "\t"))
+		}
+
+		row := []string{fmt.Sprintf("day %02d", d.Day.Day), d.Status(), check(d.HasInput), check(d.HasPrompt)}
+		if opts.Tests && d.TestErr != nil {
+			row = append(row, "error", "error")
+		} else if opts.Tests {
+			row = append(row, check(d.Examples[0]), check(d.Examples[1]))
+		}
+		row = append(row, check(d.Accepted[0]), check(d.Accepted[1]), stars(d.StarCount()))
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func check(ok bool) string {
+	if ok {
+		return "✓"
+	}
+	return "-"
+}
+
+func stars(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return strings.Repeat("*", n)
+}
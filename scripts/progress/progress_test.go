@@ -0,0 +1,134 @@
+package progress
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/days"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+)
+
+func TestExamplesPassed(t *testing.T) {
+	halfDone := days.Day{Stubs: [2]bool{false, true}}
+	done := days.Day{}
+
+	tests := []struct {
+		name    string
+		day     days.Day
+		results []days.TestResult
+		want    [2]bool
+	}{
+		{
+			name: "both pass",
+			day:  done,
+			results: []days.TestResult{
+				{Name: "Test_part1/example", Passed: true},
+				{Name: "Test_part2/example", Passed: true},
+			},
+			want: [2]bool{true, true},
+		},
+		{
+			name: "one of two examples fails",
+			day:  done,
+			results: []days.TestResult{
+				{Name: "Test_part1/example", Passed: true},
+				{Name: "Test_part2/example", Passed: true},
+				{Name: "Test_part2/example2"},
+			},
+			want: [2]bool{true, false},
+		},
+		{
+			name: "stub passing its example doesn't count",
+			day:  halfDone,
+			results: []days.TestResult{
+				{Name: "Test_part1/example", Passed: true},
+				{Name: "Test_part2/example", Passed: true},
+			},
+			want: [2]bool{true, false},
+		},
+		{
+			name: "no examples",
+			day:  done,
+			results: []days.TestResult{
+				{Name: "Test_part1/actual", Passed: true},
+			},
+			want: [2]bool{false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := examplesPassed(tt.day, tt.results); got != tt.want {
+				t.Errorf("examplesPassed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteGrid(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "2023", "day01")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc part1(input string) int {\n\treturn 1\n}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "input.txt"), []byte("1"), 0644)
+
+	store, err := records.Open(filepath.Join(root, records.DefaultPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Add(records.Run{Year: 2023, Day: 1, Part: 1, Answer: "1"})
+	store.Accept(2023, 1, 1)
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "from records",
+			want: "2023    status  input  prompt  part 1  part 2  stars\n" +
+				"day 01  part 1  ✓      -       ✓       -       *\n",
+		},
+		{
+			name: "with calendar",
+			opts: Options{Calendars: map[int]map[int]int{2023: {1: 2}}},
+			want: "2023    status  input  prompt  part 1  part 2  stars\n" +
+				"day 01  part 1  ✓      -       ✓       -       **\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress, err := Collect(context.Background(), root, store, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var sb strings.Builder
+			if err := WriteGrid(&sb, progress, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			if sb.String() != tt.want {
+				t.Errorf("WriteGrid() =\n%s\nwant\n%s", sb.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteGrid_testErr(t *testing.T) {
+	progress := []Day{
+		{Day: days.Day{Year: 2023, Day: 1, HasInput: true}, Examples: [2]bool{true, true}, Stars: -1},
+		{Day: days.Day{Year: 2023, Day: 2, HasInput: true}, TestErr: errors.New("build failed"), Stars: -1},
+	}
+	var sb strings.Builder
+	if err := WriteGrid(&sb, progress, Options{Tests: true}); err != nil {
+		t.Fatal(err)
+	}
+	want := "2023    status  input  prompt  example 1  example 2  part 1  part 2  stars\n" +
+		"day 01  done    ✓      -       ✓          ✓          -       -       -\n" +
+		"day 02  done    ✓      -       error      error      -       -       -\n"
+	if sb.String() != want {
+		t.Errorf("WriteGrid() =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
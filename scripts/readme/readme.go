@@ -0,0 +1,135 @@
+// Package readme regenerates the progress tables in README.md, between the
+// start and end markers, leaving the rest of the file alone.
+package readme
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/progress"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+)
+
+const (
+	StartMarker = "<!-- progress:start -->"
+	EndMarker   = "<!-- progress:end -->"
+)
+
+var errNoMarkers = errors.New("README needs a " + StartMarker + " line followed by a " + EndMarker + " line")
+
+// Update rewrites the progress section of the README at filename.
+func Update(filename string, root string, found []progress.Day, store *records.Store) error {
+	doc, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	updated, err := Replace(string(doc), Tables(root, found, store))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(updated), 0644)
+}
+
+// Replace swaps what's between the markers in doc for section.
+func Replace(doc, section string) (string, error) {
+	start := strings.Index(doc, StartMarker)
+	end := strings.Index(doc, EndMarker)
+	if start == -1 || end == -1 || end < start {
+		return "", errNoMarkers
+	}
+	start += len(StartMarker)
+	return doc[:start] + "\n" + section + doc[end:], nil
+}
+
+// Tables is a Markdown table per year of each day's title, stars, mean time
+// from the latest aoc bench and a link to its code. Links are relative to
+// root.
+func Tables(root string, found []progress.Day, store *records.Store) string {
+	var sb strings.Builder
+	for i, d := range found {
+		if i == 0 || found[i-1].Year != d.Year {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			fmt.Fprintf(&sb, "### %d\n\n", d.Year)
+			sb.WriteString("| Day | Title | Stars | Part 1 | Part 2 | Code |\n")
+			sb.WriteString("| ---: | --- | :---: | ---: | ---: | --- |\n")
+		}
+
+		link, err := filepath.Rel(root, d.File("main.go"))
+		if err != nil {
+			link = d.File("main.go")
+		}
+		fmt.Fprintf(&sb, "| %d | %s | %s | %s | %s | [main.go](%s) |\n",
+			d.Day.Day, title(d), stars(d.StarCount()),
+			runtime(store, d, 1), runtime(store, d, 2), filepath.ToSlash(link))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// title reads the puzzle name off the first line of prompt.md, which looks
+// like "--- Day 1: Trebuchet?! ---"
+func title(d progress.Day) string {
+	file, err := os.Open(d.File("prompt.md"))
+	if err != nil {
+		return "-"
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		line = strings.Trim(line, "- ")
+		if _, name, ok := strings.Cut(line, ": "); ok {
+			line = name
+		}
+		return strings.ReplaceAll(line, "|", `\|`)
+	}
+	return "-"
+}
+
+func stars(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return strings.Repeat("⭐", n)
+}
+
+// runtime is the mean time of the latest benchmark of an implemented part
+func runtime(store *records.Store, d progress.Day, part int) string {
+	if !d.Implemented(part) {
+		return "-"
+	}
+	run, ok := store.LatestBench(d.Year, d.Day.Day, part)
+	if !ok {
+		return "-"
+	}
+	return formatDuration(run.Duration)
+}
+
+// formatDuration rounds to 3 significant figures so the README doesn't churn
+// with noise on every run
+func formatDuration(d time.Duration) string {
+	for _, unit := range []time.Duration{time.Second, time.Millisecond, time.Microsecond} {
+		if d >= unit {
+			round := unit / 100
+			if d >= 10*unit {
+				round = unit / 10
+			}
+			if d >= 100*unit {
+				round = unit
+			}
+			return d.Round(round).String()
+		}
+	}
+	return d.String()
+}
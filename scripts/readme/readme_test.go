@@ -0,0 +1,100 @@
+package readme
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/progress"
+	"github.com/Kris-Pelteshki/aoc_2023/scripts/records"
+)
+
+func TestReplace(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "replaces between markers",
+			doc:  "# AoC\n" + StartMarker + "\nold table\n" + EndMarker + "\nfooter\n",
+			want: "# AoC\n" + StartMarker + "\nnew table\n" + EndMarker + "\nfooter\n",
+		},
+		{
+			name: "empty section",
+			doc:  StartMarker + EndMarker,
+			want: StartMarker + "\nnew table\n" + EndMarker,
+		},
+		{
+			name:    "no markers",
+			doc:     "# AoC\n",
+			wantErr: true,
+		},
+		{
+			name:    "markers out of order",
+			doc:     EndMarker + StartMarker,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Replace(tt.doc, "new table\n")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Replace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Replace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTables(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "2023", "day01")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc part1(input string) int {\n\treturn 1\n}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "prompt.md"), []byte("--- Day 1: Trebuchet?! ---\nSomething is wrong\n"), 0644)
+
+	store, err := records.Open(filepath.Join(root, records.DefaultPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Add(records.Run{Year: 2023, Day: 1, Part: 1, Answer: "142", Duration: 1234567 * time.Nanosecond, Runs: 10})
+	store.Accept(2023, 1, 1)
+	// single runs are too noisy for the README
+	store.Add(records.Run{Year: 2023, Day: 1, Part: 1, Answer: "142", Duration: time.Second})
+
+	found, err := progress.Collect(context.Background(), root, store, progress.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "### 2023\n\n" +
+		"| Day | Title | Stars | Part 1 | Part 2 | Code |\n" +
+		"| ---: | --- | :---: | ---: | ---: | --- |\n" +
+		"| 1 | Trebuchet?! | ⭐ | 1.23ms | - | [main.go](2023/day01/main.go) |\n\n"
+	if got := Tables(root, found, store); got != want {
+		t.Errorf("Tables() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{1234567890, "1.23s"},
+		{12345678, "12.3ms"},
+		{123456, "123µs"},
+		{999, "999ns"},
+	}
+	for _, tt := range tests {
+		if got := formatDuration(tt.d); got != tt.want {
+			t.Errorf("formatDuration(%d) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
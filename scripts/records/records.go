@@ -0,0 +1,143 @@
+// Package records keeps a local history of answers and how long parts took,
+// so tools can show progress without running every day again.
+package records
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Run is one part run to completion.
+type Run struct {
+	Year     int           `json:"year"`
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   string        `json:"answer"`
+	Duration time.Duration `json:"duration"`
+	At       time.Time     `json:"at"`
+	// Runs is how many times aoc bench ran the part, Duration is their
+	// mean. It's 0 for a single run.
+	Runs int `json:"runs,omitempty"`
+	// Accepted is set once the answer has been submitted and was right
+	Accepted bool `json:"accepted,omitempty"`
+}
+
+// Store is a JSON file of runs. It's safe for concurrent use.
+type Store struct {
+	path string
+
+	mu   sync.Mutex
+	runs []Run
+}
+
+// DefaultPath is where the store lives, relative to the repo root. It's
+// ignored by git since answers are per user.
+const DefaultPath = ".aoc/runs.json"
+
+// Open reads the store at path, a missing file is an empty store.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.runs); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add records run and saves the store.
+func (s *Store) Add(run Run) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if run.At.IsZero() {
+		run.At = time.Now()
+	}
+	s.runs = append(s.runs, run)
+	return s.save()
+}
+
+// Accept marks the latest run of a part as the accepted answer.
+func (s *Store) Accept(year, day, part int) (Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		run := &s.runs[i]
+		if run.Year == year && run.Day == day && run.Part == part {
+			run.Accepted = true
+			return *run, s.save()
+		}
+	}
+	return Run{}, fmt.Errorf("no runs of %d day %02d part %d", year, day, part)
+}
+
+// save writes the store, the lock must be held
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(s.runs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// History returns the runs of a part, oldest first.
+func (s *Store) History(year, day, part int) []Run {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var runs []Run
+	for _, run := range s.runs {
+		if run.Year == year && run.Day == day && run.Part == part {
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+// Latest returns the most recent run of a part.
+func (s *Store) Latest(year, day, part int) (Run, bool) {
+	runs := s.History(year, day, part)
+	if len(runs) == 0 {
+		return Run{}, false
+	}
+	return runs[len(runs)-1], true
+}
+
+// LatestBench returns the most recent benchmark of a part, which times it
+// more steadily than a single run.
+func (s *Store) LatestBench(year, day, part int) (Run, bool) {
+	runs := s.History(year, day, part)
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].Runs > 0 {
+			return runs[i], true
+		}
+	}
+	return Run{}, false
+}
+
+// Accepted returns the latest accepted run of a part.
+func (s *Store) Accepted(year, day, part int) (Run, bool) {
+	runs := s.History(year, day, part)
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].Accepted {
+			return runs[i], true
+		}
+	}
+	return Run{}, false
+}
@@ -0,0 +1,56 @@
+package records
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runs.json")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runs := []Run{
+		{Year: 2023, Day: 1, Part: 1, Answer: "1", Duration: time.Millisecond, Runs: 10},
+		{Year: 2023, Day: 1, Part: 1, Answer: "2", Duration: 2 * time.Millisecond},
+		{Year: 2023, Day: 1, Part: 2, Answer: "3"},
+	}
+	for _, run := range runs {
+		if err := store.Add(run); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.Accept(2023, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Accept(2023, 2, 1); err == nil {
+		t.Error("Accept() without a run should error")
+	}
+
+	// everything should survive reopening
+	store, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(store.History(2023, 1, 1)); got != 2 {
+		t.Errorf("History() has %d runs, want 2", got)
+	}
+	if latest, _ := store.Latest(2023, 1, 1); latest.Answer != "2" || latest.Duration != 2*time.Millisecond {
+		t.Errorf("Latest() = %+v, want answer 2 in 2ms", latest)
+	}
+	if bench, ok := store.LatestBench(2023, 1, 1); !ok || bench.Duration != time.Millisecond || bench.Runs != 10 {
+		t.Errorf("LatestBench() = %+v, %v, want 10 runs in 1ms", bench, ok)
+	}
+	if _, ok := store.LatestBench(2023, 1, 2); ok {
+		t.Error("LatestBench() for a part that was never benchmarked should be false")
+	}
+	if accepted, ok := store.Accepted(2023, 1, 1); !ok || accepted.Answer != "2" {
+		t.Errorf("Accepted() = %+v, %v, want answer 2", accepted, ok)
+	}
+	if _, ok := store.Accepted(2023, 1, 2); ok {
+		t.Error("Accepted() for a part that was never accepted should be false")
+	}
+}
@@ -12,10 +12,11 @@ import (
 	"github.com/Kris-Pelteshki/aoc_2023/util"
 )
 
-//go:embed tmpls/*.go
+//go:embed tmpls/*.go tmpls/input.txt.placeholder
 var fs embed.FS
 
-// Run makes a skeleton main.go and main_test.go file for the given day and year
+// Run makes a skeleton main.go and main_test.go file for the given day and
+// year, and the input.txt.placeholder that lets them build without an input
 func Run(day, year int) {
 	if day > 25 || day <= 0 {
 		log.Fatalf("invalid -day value, must be 1 through 25, got %v", day)
@@ -52,6 +53,15 @@ func Run(day, year int) {
 
 	ts.ExecuteTemplate(mainFile, "main.go", nil)
 	ts.ExecuteTemplate(testFile, "main_test.go", nil)
+
+	placeholder, err := fs.ReadFile("tmpls/input.txt.placeholder")
+	if err != nil {
+		log.Fatalf("reading input.txt.placeholder: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(filepath.Dir(mainFilename), "input.txt.placeholder"), placeholder, 0644)
+	if err != nil {
+		log.Fatalf("writing input.txt.placeholder: %v", err)
+	}
 	fmt.Printf("templates made for %d-day%d\n", year, day)
 }
 
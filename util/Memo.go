@@ -0,0 +1,99 @@
+package util
+
+import "container/list"
+
+// Memo caches the results of a recursive function. The function gets a
+// recurse callback to call instead of itself so the inner calls are cached
+// too:
+//
+//	fib := util.NewMemo(func(fib func(int) int, n int) int {
+//		if n < 2 {
+//			return n
+//		}
+//		return fib(n-1) + fib(n-2)
+//	})
+//	fib.Get(90)
+type Memo[K comparable, V any] struct {
+	fn      func(recurse func(K) V, key K) V
+	maxSize int
+
+	cache map[K]*list.Element
+	// most recently used at the front, the back is evicted when bounded
+	recent *list.List
+
+	hits   int
+	misses int
+}
+
+type memoEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// MemoStats counts how a Memo has been used since it was made or last Reset.
+type MemoStats struct {
+	Hits   int
+	Misses int
+	Size   int
+}
+
+// NewMemo makes an unbounded Memo for fn.
+func NewMemo[K comparable, V any](fn func(recurse func(K) V, key K) V) *Memo[K, V] {
+	return NewBoundedMemo(0, fn)
+}
+
+// NewBoundedMemo makes a Memo that keeps at most maxSize results, evicting the
+// least recently used one when full. A maxSize of 0 or less means no limit.
+func NewBoundedMemo[K comparable, V any](maxSize int, fn func(recurse func(K) V, key K) V) *Memo[K, V] {
+	return &Memo[K, V]{
+		fn:      fn,
+		maxSize: maxSize,
+		cache:   make(map[K]*list.Element),
+		recent:  list.New(),
+	}
+}
+
+// Get returns the cached result for key, calling the function on a miss.
+func (m *Memo[K, V]) Get(key K) V {
+	if elem, ok := m.cache[key]; ok {
+		m.hits++
+		if m.maxSize > 0 {
+			m.recent.MoveToFront(elem)
+		}
+		return elem.Value.(*memoEntry[K, V]).value
+	}
+
+	m.misses++
+	value := m.fn(m.Get, key)
+
+	// a recursive call may have stored key already
+	if elem, ok := m.cache[key]; ok {
+		elem.Value.(*memoEntry[K, V]).value = value
+		return value
+	}
+
+	m.cache[key] = m.recent.PushFront(&memoEntry[K, V]{key, value})
+	if m.maxSize > 0 && m.recent.Len() > m.maxSize {
+		oldest := m.recent.Back()
+		m.recent.Remove(oldest)
+		delete(m.cache, oldest.Value.(*memoEntry[K, V]).key)
+	}
+	return value
+}
+
+// Stats returns the hit and miss counts and the number of cached results.
+func (m *Memo[K, V]) Stats() MemoStats {
+	return MemoStats{
+		Hits:   m.hits,
+		Misses: m.misses,
+		Size:   len(m.cache),
+	}
+}
+
+// Reset clears the cache and stats.
+func (m *Memo[K, V]) Reset() {
+	m.cache = make(map[K]*list.Element)
+	m.recent.Init()
+	m.hits = 0
+	m.misses = 0
+}
@@ -0,0 +1,53 @@
+package util
+
+import "testing"
+
+func TestMemo(t *testing.T) {
+	calls := 0
+	fib := NewMemo(func(fib func(int) int, n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	})
+
+	if got := fib.Get(90); got != 2880067194370816120 {
+		t.Errorf("fib.Get(90) = %v, want 2880067194370816120", got)
+	}
+	if calls != 91 {
+		t.Errorf("fib called %v times, want 91", calls)
+	}
+
+	fib.Get(90)
+	stats := fib.Stats()
+	if stats.Misses != 91 || stats.Hits != 89 || stats.Size != 91 {
+		t.Errorf("Stats() = %+v, want 89 hits, 91 misses and size 91", stats)
+	}
+
+	fib.Reset()
+	if stats := fib.Stats(); stats != (MemoStats{}) {
+		t.Errorf("Stats() after Reset = %+v, want zero", stats)
+	}
+}
+
+func TestBoundedMemo(t *testing.T) {
+	square := NewBoundedMemo(2, func(_ func(int) int, n int) int {
+		return n * n
+	})
+
+	square.Get(1)
+	square.Get(2)
+	square.Get(1) // 2 is now the least recently used
+	square.Get(3)
+	if got := square.Stats().Size; got != 2 {
+		t.Errorf("Stats().Size = %v, want 2", got)
+	}
+
+	square.Get(1)
+	square.Get(2)
+	stats := square.Stats()
+	if stats.Hits != 2 || stats.Misses != 4 {
+		t.Errorf("Stats() = %+v, want 2 hits and 4 misses", stats)
+	}
+}
@@ -1,7 +1,9 @@
 package util
 
-import "strings"
+import "github.com/Kris-Pelteshki/aoc_2023/util/inputs"
 
+// SplitLines splits input into lines, ignoring \r\n line endings and trailing
+// whitespace. See the inputs package for blocks and sections.
 func SplitLines(input string) []string {
-	return strings.Split(input, "\n")
+	return inputs.Lines(input)
 }
@@ -0,0 +1,126 @@
+// Package geometry has helpers for polygons on integer lattice points, like a
+// loop traced through a grid or a trench dug from a list of instructions.
+package geometry
+
+import "github.com/Kris-Pelteshki/aoc_2023/util/maths"
+
+type Point struct {
+	X, Y int
+}
+
+// Polygon is a closed loop of vertices in order, the last vertex connects back
+// to the first. Vertices can be every cell of a grid loop or only the corners.
+type Polygon []Point
+
+// DoubleArea returns twice the area enclosed by the polygon using the shoelace
+// formula. Doubling keeps the result an integer for any lattice polygon.
+func (poly Polygon) DoubleArea() int {
+	sum := 0
+	for i, p := range poly {
+		next := poly[(i+1)%len(poly)]
+		sum += p.X*next.Y - next.X*p.Y
+	}
+	return maths.Abs(sum)
+}
+
+// Area returns the area enclosed by the polygon, rounded down for polygons
+// with a half unit area.
+func (poly Polygon) Area() int {
+	return poly.DoubleArea() / 2
+}
+
+// BoundaryPoints returns the number of lattice points on the edges of the
+// polygon. Edges can be any length and direction, each one contributes
+// gcd(|dx|, |dy|) points.
+func (poly Polygon) BoundaryPoints() int {
+	count := 0
+	for i, p := range poly {
+		next := poly[(i+1)%len(poly)]
+		count += maths.GCD(maths.Abs(next.X-p.X), maths.Abs(next.Y-p.Y))
+	}
+	return count
+}
+
+// InteriorPoints returns the number of lattice points strictly inside the
+// polygon using Pick's theorem: A = I + B/2 - 1.
+func (poly Polygon) InteriorPoints() int {
+	return (poly.DoubleArea() - poly.BoundaryPoints() + 2) / 2
+}
+
+// TotalPoints returns the number of lattice points inside or on the polygon,
+// ie the number of grid cells a trench along the edges encloses.
+func (poly Polygon) TotalPoints() int {
+	return poly.InteriorPoints() + poly.BoundaryPoints()
+}
+
+// OnBoundary reports whether p lies on one of the polygon's edges.
+func (poly Polygon) OnBoundary(p Point) bool {
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		if cross(a, b, p) == 0 &&
+			p.X >= maths.Min(a.X, b.X) && p.X <= maths.Max(a.X, b.X) &&
+			p.Y >= maths.Min(a.Y, b.Y) && p.Y <= maths.Max(a.Y, b.Y) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsRayCast reports whether p is strictly inside the polygon by counting
+// how many edges a ray going right from p crosses. Points on an edge are not
+// inside.
+func (poly Polygon) ContainsRayCast(p Point) bool {
+	if poly.OnBoundary(p) {
+		return false
+	}
+
+	inside := false
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		// half open so a vertex on the ray is only counted for one of its edges
+		if (a.Y > p.Y) == (b.Y > p.Y) {
+			continue
+		}
+		// crossing is right of p when p is left of the edge pointing up in y
+		lower, upper := a, b
+		if a.Y > b.Y {
+			lower, upper = b, a
+		}
+		if cross(lower, upper, p) > 0 {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// WindingNumber returns how many times the polygon winds around p,
+// counterclockwise loops are positive. Returns 0 for points on an edge.
+func (poly Polygon) WindingNumber(p Point) (winding int) {
+	if poly.OnBoundary(p) {
+		return 0
+	}
+
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		if a.Y <= p.Y {
+			if b.Y > p.Y && cross(a, b, p) > 0 {
+				winding++
+			}
+		} else if b.Y <= p.Y && cross(a, b, p) < 0 {
+			winding--
+		}
+	}
+	return winding
+}
+
+// ContainsWinding reports whether p is strictly inside the polygon using its
+// winding number, which also handles self intersecting polygons.
+func (poly Polygon) ContainsWinding(p Point) bool {
+	return poly.WindingNumber(p) != 0
+}
+
+// cross is positive when p is left of the line from a to b, negative when it
+// is right and 0 when the three points are collinear.
+func cross(a, b, p Point) int {
+	return (b.X-a.X)*(p.Y-a.Y) - (p.X-a.X)*(b.Y-a.Y)
+}
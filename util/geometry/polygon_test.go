@@ -0,0 +1,66 @@
+package geometry
+
+import "testing"
+
+// 6x4 rectangle given by its corners
+var rect = Polygon{{0, 0}, {6, 0}, {6, 4}, {0, 4}}
+
+// L shape traced clockwise, the notch is the top right 2x2 square
+var lShape = Polygon{{0, 0}, {0, 4}, {4, 4}, {4, 2}, {2, 2}, {2, 0}}
+
+func TestPolygonCounts(t *testing.T) {
+	tests := []struct {
+		name                                  string
+		poly                                  Polygon
+		doubleArea, boundary, interior, total int
+	}{
+		{"rectangle", rect, 48, 20, 15, 35},
+		{"l shape", lShape, 24, 16, 5, 21},
+		{"triangle", Polygon{{0, 0}, {4, 0}, {0, 3}}, 12, 8, 3, 11},
+		{"unit steps", Polygon{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}, {1, 2}, {0, 2}, {0, 1}}, 8, 8, 1, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.poly.DoubleArea(); got != tt.doubleArea {
+				t.Errorf("DoubleArea() = %v, want %v", got, tt.doubleArea)
+			}
+			if got := tt.poly.BoundaryPoints(); got != tt.boundary {
+				t.Errorf("BoundaryPoints() = %v, want %v", got, tt.boundary)
+			}
+			if got := tt.poly.InteriorPoints(); got != tt.interior {
+				t.Errorf("InteriorPoints() = %v, want %v", got, tt.interior)
+			}
+			if got := tt.poly.TotalPoints(); got != tt.total {
+				t.Errorf("TotalPoints() = %v, want %v", got, tt.total)
+			}
+		})
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	tests := []struct {
+		name   string
+		poly   Polygon
+		p      Point
+		inside bool
+	}{
+		{"rect center", rect, Point{3, 2}, true},
+		{"rect edge", rect, Point{6, 1}, false},
+		{"rect corner", rect, Point{0, 0}, false},
+		{"rect outside", rect, Point{7, 2}, false},
+		{"rect level with corner", rect, Point{-1, 4}, false},
+		{"l shape inside", lShape, Point{1, 3}, true},
+		{"l shape notch", lShape, Point{3, 1}, false},
+		{"l shape level with inner corner", lShape, Point{1, 2}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.poly.ContainsRayCast(tt.p); got != tt.inside {
+				t.Errorf("ContainsRayCast() = %v, want %v", got, tt.inside)
+			}
+			if got := tt.poly.ContainsWinding(tt.p); got != tt.inside {
+				t.Errorf("ContainsWinding() = %v, want %v", got, tt.inside)
+			}
+		})
+	}
+}
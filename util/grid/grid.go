@@ -0,0 +1,106 @@
+// Package grid is a shared 2D grid of characters for the many days whose
+// input is a map, plus a terminal renderer for debugging them.
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/geometry"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+)
+
+type Point = geometry.Point
+
+// Grid is a rectangle of ASCII cells, indexed by Point{X: column, Y: row}.
+type Grid struct {
+	Width, Height int
+	Cells         [][]byte
+}
+
+// Parse makes a grid from the lines of input, short lines are padded with '.'.
+func Parse(input string) *Grid {
+	return FromRows(inputs.Lines(input))
+}
+
+// FromRows makes a grid from rows of text, short rows are padded with '.'.
+func FromRows(rows []string) *Grid {
+	g := &Grid{Height: len(rows)}
+	for _, row := range rows {
+		g.Width = max(g.Width, len(row))
+	}
+
+	g.Cells = make([][]byte, g.Height)
+	for y, row := range rows {
+		g.Cells[y] = []byte(row + strings.Repeat(".", g.Width-len(row)))
+	}
+	return g
+}
+
+// CheckRows returns an error unless rows are a non-empty rectangle made only
+// of the bytes in cells, for parsers that index a map without bounds checks.
+func CheckRows(rows []string, cells string) error {
+	if len(rows) == 0 || rows[0] == "" {
+		return errors.New("empty grid")
+	}
+	for y, row := range rows {
+		if len(row) != len(rows[0]) {
+			return fmt.Errorf("row %d is %d wide, want %d", y+1, len(row), len(rows[0]))
+		}
+		for x := 0; x < len(row); x++ {
+			if strings.IndexByte(cells, row[x]) < 0 {
+				return fmt.Errorf("row %d column %d: unexpected %q", y+1, x+1, row[x])
+			}
+		}
+	}
+	return nil
+}
+
+// New makes a Width x Height grid filled with fill.
+func New(width, height int, fill byte) *Grid {
+	g := &Grid{Width: width, Height: height, Cells: make([][]byte, height)}
+	for y := range g.Cells {
+		g.Cells[y] = []byte(strings.Repeat(string(fill), width))
+	}
+	return g
+}
+
+func (g *Grid) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.Width && p.Y >= 0 && p.Y < g.Height
+}
+
+// At returns the cell at p, or 0 outside the grid.
+func (g *Grid) At(p Point) byte {
+	if !g.InBounds(p) {
+		return 0
+	}
+	return g.Cells[p.Y][p.X]
+}
+
+func (g *Grid) Set(p Point, cell byte) {
+	g.Cells[p.Y][p.X] = cell
+}
+
+// Find returns the first point holding cell, reading row by row.
+func (g *Grid) Find(cell byte) (Point, bool) {
+	for y, row := range g.Cells {
+		for x, c := range row {
+			if c == cell {
+				return Point{X: x, Y: y}, true
+			}
+		}
+	}
+	return Point{}, false
+}
+
+func (g *Grid) String() string {
+	var sb strings.Builder
+	for y, row := range g.Cells {
+		if y > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.Write(row)
+	}
+	return sb.String()
+}
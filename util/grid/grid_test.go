@@ -0,0 +1,29 @@
+package grid
+
+import "testing"
+
+func TestCheckRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []string
+		wantErr string
+	}{
+		{"ok", []string{"#.", ".#"}, ""},
+		{"empty", nil, "empty grid"},
+		{"empty row", []string{""}, "empty grid"},
+		{"ragged", []string{"#.", "#"}, "row 2 is 1 wide, want 2"},
+		{"unknown cell", []string{"#.", ".x"}, `row 2 column 2: unexpected 'x'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRows(tt.rows, "#.")
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Errorf("CheckRows() = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package grid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Color is an ANSI SGR foreground colour code.
+type Color int
+
+const (
+	Red     Color = 31
+	Green   Color = 32
+	Yellow  Color = 33
+	Blue    Color = 34
+	Magenta Color = 35
+	Cyan    Color = 36
+)
+
+// Layer highlights some cells of a grid. Layers are drawn in order so later
+// ones win when two style the same cell.
+type Layer interface {
+	// Style returns the SGR parameters for p, like "1;31" for bold red, or
+	// false to leave the cell alone
+	Style(p Point) (sgr string, ok bool)
+}
+
+// cachingLayer is a layer that works something out from its data the first
+// time it styles a cell. Render resets it first, so a layer can be changed
+// between renders, like a path growing one step per frame.
+type cachingLayer interface {
+	reset()
+}
+
+// SetLayer colours every point in a set, like the tiles a BFS visited.
+type SetLayer struct {
+	Points map[Point]bool
+	Color  Color
+}
+
+func (l SetLayer) Style(p Point) (string, bool) {
+	if !l.Points[p] {
+		return "", false
+	}
+	return fmt.Sprint(int(l.Color)), true
+}
+
+// PathLayer colours the points of a path in bold, the start and end of the
+// path are also inverted so they stand out. Path can change between renders,
+// but not during one.
+type PathLayer struct {
+	Path  []Point
+	Color Color
+
+	points map[Point]bool
+}
+
+func (l *PathLayer) reset() {
+	l.points = nil
+}
+
+func (l *PathLayer) Style(p Point) (string, bool) {
+	if l.points == nil {
+		l.points = make(map[Point]bool, len(l.Path))
+		for _, point := range l.Path {
+			l.points[point] = true
+		}
+	}
+	if !l.points[p] {
+		return "", false
+	}
+	if len(l.Path) > 0 && (p == l.Path[0] || p == l.Path[len(l.Path)-1]) {
+		return fmt.Sprintf("1;7;%d", l.Color), true
+	}
+	return fmt.Sprintf("1;%d", l.Color), true
+}
+
+// ValueLayer shades cells by a value, like a distance or heat loss, on a
+// background scale from blue for the smallest value to red for the largest.
+// Values can change between renders, but not during one.
+type ValueLayer struct {
+	Values map[Point]int
+
+	lo, hi  int
+	scanned bool
+}
+
+// heatScale is the xterm 256 colour cube from blue to red through green
+var heatScale = []int{21, 27, 33, 39, 45, 51, 50, 49, 48, 47, 46, 82, 118, 154, 190, 226, 220, 214, 208, 202, 196}
+
+func (l *ValueLayer) reset() {
+	l.scanned = false
+}
+
+func (l *ValueLayer) Style(p Point) (string, bool) {
+	value, ok := l.Values[p]
+	if !ok {
+		return "", false
+	}
+
+	if !l.scanned {
+		l.lo, l.hi = value, value
+		for _, v := range l.Values {
+			l.lo, l.hi = min(l.lo, v), max(l.hi, v)
+		}
+		l.scanned = true
+	}
+	idx := 0
+	if l.hi > l.lo {
+		idx = (value - l.lo) * (len(heatScale) - 1) / (l.hi - l.lo)
+	}
+	return fmt.Sprintf("30;48;5;%d", heatScale[idx]), true
+}
+
+// boxDrawing swaps pipe symbols for their box drawing characters
+var boxDrawing = map[byte]rune{
+	'|': '│',
+	'-': '─',
+	'L': '└',
+	'J': '┘',
+	'7': '┐',
+	'F': '┌',
+}
+
+// ColorMode decides when Render writes ANSI escapes.
+type ColorMode int
+
+const (
+	// ColorAuto colours output only when writing to a terminal and NO_COLOR
+	// isn't set
+	ColorAuto ColorMode = iota
+	ColorAlways
+	ColorNever
+)
+
+// Viewport is the inclusive rectangle of cells to render.
+type Viewport struct {
+	Min, Max Point
+}
+
+// Renderer prints grids to a terminal with highlighted layers.
+type Renderer struct {
+	Layers []Layer
+	// BoxDrawing draws pipe symbols like |-LJ7F as box drawing lines
+	BoxDrawing bool
+	// Viewport crops the output, nil renders the whole grid
+	Viewport *Viewport
+	Color    ColorMode
+}
+
+// Render writes g to w, one line per row.
+func (r *Renderer) Render(w io.Writer, g *Grid) error {
+	useColor := r.useColor(w)
+	out := bufio.NewWriter(w)
+	for _, layer := range r.Layers {
+		if cached, ok := layer.(cachingLayer); ok {
+			cached.reset()
+		}
+	}
+
+	view := Viewport{Max: Point{X: g.Width - 1, Y: g.Height - 1}}
+	if r.Viewport != nil {
+		view.Min = Point{X: max(r.Viewport.Min.X, 0), Y: max(r.Viewport.Min.Y, 0)}
+		view.Max = Point{X: min(r.Viewport.Max.X, g.Width-1), Y: min(r.Viewport.Max.Y, g.Height-1)}
+	}
+
+	for y := view.Min.Y; y <= view.Max.Y; y++ {
+		for x := view.Min.X; x <= view.Max.X; x++ {
+			p := Point{X: x, Y: y}
+
+			cell := rune(g.At(p))
+			if box, ok := boxDrawing[g.At(p)]; ok && r.BoxDrawing {
+				cell = box
+			}
+
+			sgr := ""
+			if useColor {
+				for _, layer := range r.Layers {
+					if style, ok := layer.Style(p); ok {
+						sgr = style
+					}
+				}
+			}
+
+			if sgr != "" {
+				fmt.Fprintf(out, "\x1b[%sm%c\x1b[0m", sgr, cell)
+			} else {
+				out.WriteRune(cell)
+			}
+		}
+		out.WriteByte('\n')
+	}
+	return out.Flush()
+}
+
+// Print renders g to stdout.
+func (r *Renderer) Print(g *Grid) error {
+	return r.Render(os.Stdout, g)
+}
+
+func (r *Renderer) useColor(w io.Writer) bool {
+	switch r.Color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,76 @@
+package grid
+
+import (
+	"strings"
+	"testing"
+)
+
+var pipes = `..F7.
+.FJ|.
+SJ.L7
+|F--J
+LJ...`
+
+func TestRenderPlain(t *testing.T) {
+	g := Parse(pipes)
+	var sb strings.Builder
+	r := Renderer{BoxDrawing: true, Viewport: &Viewport{Min: Point{X: 1, Y: 1}, Max: Point{X: 9, Y: 2}}}
+	if err := r.Render(&sb, g); err != nil {
+		t.Fatal(err)
+	}
+	if want := "┌┘│.\n┘.└┐\n"; sb.String() != want {
+		t.Errorf("Render() = %q, want %q", sb.String(), want)
+	}
+
+	// a strings.Builder isn't a terminal, so auto mode has no escapes
+	sb.Reset()
+	r = Renderer{Layers: []Layer{SetLayer{map[Point]bool{{X: 0, Y: 2}: true}, Red}}}
+	r.Render(&sb, g)
+	if sb.String() != pipes+"\n" {
+		t.Errorf("Render() = %q, want the plain grid", sb.String())
+	}
+}
+
+func TestRenderLayers(t *testing.T) {
+	g := FromRows([]string{"abc"})
+	var sb strings.Builder
+	r := Renderer{
+		Color: ColorAlways,
+		Layers: []Layer{
+			SetLayer{map[Point]bool{{X: 0, Y: 0}: true, {X: 1, Y: 0}: true}, Blue},
+			&PathLayer{Path: []Point{{X: 1, Y: 0}, {X: 2, Y: 0}}, Color: Green},
+		},
+	}
+	r.Render(&sb, g)
+	want := "\x1b[34ma\x1b[0m\x1b[1;7;32mb\x1b[0m\x1b[1;7;32mc\x1b[0m\n"
+	if sb.String() != want {
+		t.Errorf("Render() = %q, want %q", sb.String(), want)
+	}
+
+	sb.Reset()
+	r = Renderer{Color: ColorAlways, Layers: []Layer{&ValueLayer{Values: map[Point]int{{X: 0, Y: 0}: 1, {X: 2, Y: 0}: 5}}}}
+	r.Render(&sb, g)
+	want = "\x1b[30;48;5;21ma\x1b[0mb\x1b[30;48;5;196mc\x1b[0m\n"
+	if sb.String() != want {
+		t.Errorf("Render() = %q, want %q", sb.String(), want)
+	}
+}
+
+func TestRenderChangedLayers(t *testing.T) {
+	g := FromRows([]string{"abcd"})
+	path := &PathLayer{Path: []Point{{X: 0, Y: 0}}, Color: Green}
+	values := &ValueLayer{Values: map[Point]int{{X: 1, Y: 0}: 1}}
+	r := Renderer{Color: ColorAlways, Layers: []Layer{values, path}}
+	var sb strings.Builder
+	r.Render(&sb, g)
+
+	// the path grows and a larger value turns up, both since the last render
+	path.Path = append(path.Path, Point{X: 3, Y: 0})
+	values.Values[Point{X: 2, Y: 0}] = 5
+	sb.Reset()
+	r.Render(&sb, g)
+	want := "\x1b[1;7;32ma\x1b[0m\x1b[30;48;5;21mb\x1b[0m\x1b[30;48;5;196mc\x1b[0m\x1b[1;7;32md\x1b[0m\n"
+	if sb.String() != want {
+		t.Errorf("Render() = %q, want %q", sb.String(), want)
+	}
+}
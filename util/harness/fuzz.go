@@ -0,0 +1,52 @@
+package harness
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+)
+
+// CheckParse runs parse on input, normalized like the days' own inputs, and
+// fails t instead of crashing if it panics. Returning an error is fine, that's
+// how parsers should reject bad input. The failure names the first line that
+// makes parse panic, found by parsing longer and longer prefixes of input.
+//
+// It's meant for fuzz targets:
+//
+//	func FuzzParseInput(f *testing.F) {
+//		f.Add(example)
+//		f.Fuzz(func(t *testing.T, input string) {
+//			harness.CheckParse(t, input, parseInput)
+//		})
+//	}
+func CheckParse[T any](t testing.TB, input string, parse func(string) (T, error)) {
+	t.Helper()
+	input = inputs.Normalize(input)
+
+	recovered := catch(func() { parse(input) })
+	if recovered == nil {
+		return
+	}
+
+	lines := strings.Split(input, "\n")
+	for i := range lines {
+		prefix := strings.Join(lines[:i+1], "\n")
+		if r := catch(func() { parse(prefix) }); r != nil {
+			t.Fatalf("parsing panicked on line %d %q: %v", i+1, lines[i], r)
+			return
+		}
+	}
+	// a prefix ending on the last line is the whole input, so this is only
+	// reached if parse doesn't panic the same way twice
+	t.Fatalf("parsing panicked: %v", recovered)
+}
+
+// catch returns what fn panicked with, or nil
+func catch(fn func()) (recovered any) {
+	defer func() {
+		recovered = recover()
+	}()
+	fn()
+	return nil
+}
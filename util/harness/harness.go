@@ -0,0 +1,63 @@
+// Package harness runs a day's parts against every named input in its inputs/
+// directory, so a solution that only works on one person's input gets caught.
+// See inputs.Dir for how the inputs and their answers are laid out. Days
+// test them with:
+//
+//	func Test_inputs(t *testing.T) {
+//		harness.Test(t, part1, part2)
+//	}
+package harness
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
+)
+
+// Test runs part1 and part2 on every named input as subtests like
+// Test_inputs/part1/alice, comparing what they print to the expected answer.
+// The inputs are read from the day's directory, where go test runs, rather
+// than embedded so they don't end up in the day's binary.
+func Test[T1, T2 any](t *testing.T, part1 func(string) T1, part2 func(string) T2) {
+	t.Helper()
+	test(t, os.DirFS("."), part1, part2)
+}
+
+func test[T1, T2 any](t *testing.T, fsys fs.FS, part1 func(string) T1, part2 func(string) T2) {
+	t.Helper()
+	loaded, err := inputs.LoadNamed(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) == 0 {
+		t.Skip("no named inputs in " + inputs.Dir + "/")
+	}
+
+	t.Run("part1", func(t *testing.T) {
+		runPart(t, loaded, 0, func(s string) any { return part1(s) })
+	})
+	t.Run("part2", func(t *testing.T) {
+		runPart(t, loaded, 1, func(s string) any { return part2(s) })
+	})
+}
+
+func runPart(t *testing.T, loaded []inputs.Named, part int, solve func(string) any) {
+	for _, in := range loaded {
+		in := in
+		t.Run(in.Name, func(t *testing.T) {
+			if in.Locked {
+				t.Skip(vault.ErrLocked)
+			}
+			if in.Want[part] == "" {
+				t.Skipf("no part%d answer for %s", part+1, in.Name)
+			}
+			if got := fmt.Sprint(solve(in.Input)); got != in.Want[part] {
+				t.Errorf("part%d(%s) = %s, want %s", part+1, in.Name, got, in.Want[part])
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package harness
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+)
+
+func sum(input string) int {
+	total := 0
+	for _, c := range input {
+		if c >= '0' && c <= '9' {
+			total += int(c - '0')
+		}
+	}
+	return total
+}
+
+func count(input string) int {
+	return len(strings.Split(input, "\n"))
+}
+
+func TestTest(t *testing.T) {
+	files := fstest.MapFS{
+		"inputs/bob.txt":       {Data: []byte("1\n2\n")},
+		"inputs/bob.answers":   {Data: []byte("part1: 3\npart2: 2\n")},
+		"inputs/alice.txt":     {Data: []byte("5\n")},
+		"inputs/alice.answers": {Data: []byte("part1: 5\n")},
+	}
+	test(t, files, sum, count)
+}
+
+func parseNumbers(input string) ([]int, error) {
+	var nums []int
+	for _, line := range strings.Split(input, "\n") {
+		// indexes blindly, like the parsers this is for
+		nums = append(nums, int(line[0]-'0'))
+	}
+	return nums, nil
+}
+
+// recorder is a testing.TB that keeps the failure instead of failing
+type recorder struct {
+	testing.TB
+	failure string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failure = fmt.Sprintf(format, args...)
+}
+
+func TestCheckParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"parses", "1\n2\r\n3\n", ""},
+		{"names the line", "1\n2\n\n4", `parsing panicked on line 3 "": runtime error: index out of range [0] with length 0`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{TB: t}
+			CheckParse(rec, tt.input, parseNumbers)
+			if rec.failure != tt.want {
+				t.Errorf("CheckParse() failed with %q, want %q", rec.failure, tt.want)
+			}
+		})
+	}
+}
+
+func randomNumbers(r *rand.Rand) string {
+	lines := make([]string, 1+r.Intn(8))
+	for i := range lines {
+		lines[i] = strconv.Itoa(r.Intn(100))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sumLines(input string) (total int) {
+	for _, line := range strings.Split(input, "\n") {
+		total += cast.MustParse[int](line)
+	}
+	return total
+}
+
+// sumSmall is sumLines with a bug for numbers over 40
+func sumSmall(input string) (total int) {
+	for _, line := range strings.Split(input, "\n") {
+		if n := cast.MustParse[int](line); n <= 40 {
+			total += n
+		}
+	}
+	return total
+}
+
+func TestOracle(t *testing.T) {
+	rec := &recorder{TB: t}
+	Oracle(rec, randomNumbers, sumLines, sumLines)
+	if rec.failure != "" {
+		t.Errorf("Oracle() on the same solution failed with %q", rec.failure)
+	}
+
+	// lines are dropped and numbers made smaller until only the first
+	// number that trips the bug is left
+	Oracle(rec, randomNumbers, sumSmall, sumLines, ShrinkLines, ShrinkNumbers)
+	if want := "got 0, want 41 on input:\n41"; !strings.HasSuffix(rec.failure, want) {
+		t.Errorf("Oracle() failed with %q, want it to end %q", rec.failure, want)
+	}
+}
+
+func TestShrinkers(t *testing.T) {
+	tests := []struct {
+		name   string
+		shrink Shrinker
+		input  string
+		want   []string
+	}{
+		{"lines", ShrinkLines, "a\nb\nc", []string{"b\nc", "a\nc", "a\nb"}},
+		{"one line", ShrinkLines, "abc", nil},
+		{"blocks", ShrinkBlocks, "a\nb\n\nc", []string{"c", "a\nb"}},
+		{"columns", ShrinkColumns, "ab\ncd", []string{"b\nd", "a\nc"}},
+		{"numbers", ShrinkNumbers, "x 10 0 1", []string{"x 0 0 1", "x 5 0 1", "x 9 0 1", "x 10 0 0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.shrink(tt.input); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("%s(%q) = %q, want %q", tt.name, tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlot(t *testing.T) {
+	if got := exponent(100, 200, time.Millisecond, 4*time.Millisecond); math.Abs(got-2) > 1e-9 {
+		t.Errorf("exponent() = %v, want 2", got)
+	}
+
+	// the middle size is noisy, the fit still sees the square
+	lengths := []int{100, 200, 400}
+	timings := []time.Duration{time.Millisecond, 8 * time.Millisecond, 16 * time.Millisecond}
+	if got := fitExponent(lengths, timings); math.Abs(got-2) > 1e-9 {
+		t.Errorf("fitExponent() = %v, want 2", got)
+	}
+
+	got := plot([]int{10, 20}, []int{100, 400}, []time.Duration{time.Second, 2 * time.Second})
+	want := "size 10           100B           1s ####################                     \n" +
+		"size 20           400B           2s ######################################## length^0.50\n"
+	if got != want {
+		t.Errorf("plot() = %q, want %q", got, want)
+	}
+}
+
+func linearWork(input string) (total int) {
+	for _, c := range input {
+		total += int(c)
+	}
+	return total
+}
+
+func BenchmarkScaling(b *testing.B) {
+	Scaling(b, func(r *rand.Rand, size int) string {
+		return RandomGrid(r, size, size, ".#")
+	}, linearWork, Linear)
+}
@@ -0,0 +1,219 @@
+package harness
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// SeedEnv overrides the seed Oracle generates inputs from, to explore other
+// inputs than the fixed default or to rerun one from a failure.
+const SeedEnv = "AOC_ORACLE_SEED"
+
+// Cases is how many random inputs Oracle tries, a tenth of it with -short.
+var Cases = 200
+
+// maxShrinks stops shrinking inputs that keep getting a little smaller
+const maxShrinks = 1000
+
+// A Shrinker returns smaller versions of input to try in place of a failing
+// one. They don't have to be valid, ones the reference panics on are skipped.
+type Shrinker func(input string) []string
+
+// Oracle checks an optimized solution against a slow reference that's
+// obviously right, on random puzzle-shaped inputs from gen. When they
+// disagree, or fast panics, the input is shrunk with shrinkers (ShrinkLines
+// if there are none) to the smallest one that still fails, and reported:
+//
+//	func Test_oracle(t *testing.T) {
+//		harness.Oracle(t, randomInput, part2, bruteForce, harness.ShrinkLines, harness.ShrinkNumbers)
+//	}
+func Oracle[T comparable](t testing.TB, gen func(r *rand.Rand) string, fast, slow func(string) T, shrinkers ...Shrinker) {
+	t.Helper()
+	if len(shrinkers) == 0 {
+		shrinkers = []Shrinker{ShrinkLines}
+	}
+
+	seed := int64(1)
+	if env := os.Getenv(SeedEnv); env != "" {
+		var err error
+		if seed, err = strconv.ParseInt(env, 10, 64); err != nil {
+			t.Fatalf("%s: %v", SeedEnv, err)
+		}
+	}
+	r := rand.New(rand.NewSource(seed))
+
+	cases := Cases
+	if testing.Short() {
+		cases = max(1, cases/10)
+	}
+
+	for i := 0; i < cases; i++ {
+		input := gen(r)
+		want, ok := solve(slow, input)
+		if !ok {
+			t.Fatalf("reference panicked on generated input, fix the generator: %v\n%s", want.recovered, input)
+			return
+		}
+		if got, _ := solve(fast, input); got.agrees(want) {
+			continue
+		}
+
+		input = shrink(input, fast, slow, shrinkers)
+		want, _ = solve(slow, input)
+		got, _ := solve(fast, input)
+		t.Fatalf("case %d of seed %d (set $%s to rerun) got %v, want %v on input:\n%s",
+			i+1, seed, SeedEnv, got, want, input)
+		return
+	}
+}
+
+// outcome is what a solution returned, or what it panicked with
+type outcome[T comparable] struct {
+	answer    T
+	recovered any
+}
+
+func (o outcome[T]) String() string {
+	if o.recovered != nil {
+		return fmt.Sprintf("panic: %v", o.recovered)
+	}
+	return fmt.Sprint(o.answer)
+}
+
+// agrees is false if either panicked, their panics could be incomparable
+func (o outcome[T]) agrees(other outcome[T]) bool {
+	return o.recovered == nil && other.recovered == nil && o.answer == other.answer
+}
+
+// solve runs solution on input, ok is false if it panicked
+func solve[T comparable](solution func(string) T, input string) (out outcome[T], ok bool) {
+	out.recovered = catch(func() { out.answer = solution(input) })
+	return out, out.recovered == nil
+}
+
+// shrink greedily takes the first smaller input that still fails until none
+// of the shrinkers find one
+func shrink[T comparable](input string, fast, slow func(string) T, shrinkers []Shrinker) string {
+	fails := func(candidate string) bool {
+		want, ok := solve(slow, candidate)
+		if !ok {
+			return false
+		}
+		got, _ := solve(fast, candidate)
+		return !got.agrees(want)
+	}
+
+	for step := 0; step < maxShrinks; step++ {
+		smaller := ""
+	search:
+		for _, shrinker := range shrinkers {
+			for _, candidate := range shrinker(input) {
+				if len(candidate) <= len(input) && candidate != input && fails(candidate) {
+					smaller = candidate
+					break search
+				}
+			}
+		}
+		if smaller == "" {
+			return input
+		}
+		input = smaller
+	}
+	return input
+}
+
+// ShrinkLines tries input without each of its lines.
+func ShrinkLines(input string) []string {
+	lines := strings.Split(input, "\n")
+	if len(lines) < 2 {
+		return nil
+	}
+	candidates := make([]string, 0, len(lines))
+	for i := range lines {
+		rest := append(append([]string{}, lines[:i]...), lines[i+1:]...)
+		candidates = append(candidates, strings.Join(rest, "\n"))
+	}
+	return candidates
+}
+
+// ShrinkBlocks tries input without each of its blocks of lines, the parts
+// between blank lines.
+func ShrinkBlocks(input string) []string {
+	blocks := strings.Split(input, "\n\n")
+	if len(blocks) < 2 {
+		return nil
+	}
+	candidates := make([]string, 0, len(blocks))
+	for i := range blocks {
+		rest := append(append([]string{}, blocks[:i]...), blocks[i+1:]...)
+		candidates = append(candidates, strings.Join(rest, "\n\n"))
+	}
+	return candidates
+}
+
+// ShrinkColumns tries input without each of its columns, cutting the same
+// byte from every line long enough to have it, so grids stay rectangular.
+func ShrinkColumns(input string) []string {
+	lines := strings.Split(input, "\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line))
+	}
+	if width < 2 {
+		return nil
+	}
+
+	candidates := make([]string, 0, width)
+	for x := 0; x < width; x++ {
+		cut := make([]string, len(lines))
+		for y, line := range lines {
+			cut[y] = line
+			if x < len(line) {
+				cut[y] = line[:x] + line[x+1:]
+			}
+		}
+		candidates = append(candidates, strings.Join(cut, "\n"))
+	}
+	return candidates
+}
+
+var number = regexp.MustCompile(`\d+`)
+
+// ShrinkNumbers tries input with each number made smaller: 0, half of it,
+// and one less.
+func ShrinkNumbers(input string) []string {
+	var candidates []string
+	for _, loc := range number.FindAllStringIndex(input, -1) {
+		n, err := strconv.ParseUint(input[loc[0]:loc[1]], 10, 64)
+		if err != nil || n == 0 {
+			continue
+		}
+		tried := map[uint64]bool{}
+		for _, smaller := range []uint64{0, n / 2, n - 1} {
+			if !tried[smaller] {
+				tried[smaller] = true
+				candidates = append(candidates, input[:loc[0]]+strconv.FormatUint(smaller, 10)+input[loc[1]:])
+			}
+		}
+	}
+	return candidates
+}
+
+// RandomGrid makes a width x height grid of cells picked at random from
+// cells, repeat a cell in it to make it more likely.
+func RandomGrid(r *rand.Rand, width, height int, cells string) string {
+	rows := make([]string, height)
+	for y := range rows {
+		row := make([]byte, width)
+		for x := range row {
+			row[x] = cells[r.Intn(len(cells))]
+		}
+		rows[y] = string(row)
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,123 @@
+package harness
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A Generator makes a valid random input of about size units from r, where a
+// unit is whatever the day's input grows by: lines, hands, nodes, or the side
+// of a grid.
+type Generator func(r *rand.Rand, size int) string
+
+// Growth is how fast a part's runtime may grow with the length of its input,
+// as the exponent of the length. It's measured against the length rather than
+// the generator's size, so a part that's linear in the cells of a grid is
+// Linear even though the cells grow with the square of the side.
+type Growth float64
+
+const (
+	Linear    Growth = 1
+	Quadratic Growth = 2
+)
+
+// tolerance is how far above its Growth a part may measure before it fails,
+// enough for an extra log factor and what noise is left after fitting every
+// size
+const tolerance = 0.5
+
+// DefaultSizes are the sizes Scaling uses when given none, doubling so each
+// step's exponent is comparable.
+var DefaultSizes = []int{50, 100, 200, 400, 800}
+
+// Scaling benchmarks part on an input from gen at each size, as sub-benchmarks
+// like Benchmark_scaling/size=400, then plots how the time per run grows with
+// the input's length. It fails if the growth fitted over all the sizes is
+// well over want, to catch solutions that are accidentally quadratic:
+//
+//	func Benchmark_scaling(b *testing.B) {
+//		harness.Scaling(b, randomInput, part1, harness.Linear)
+//	}
+//
+// The inputs are the same every run, generated from a fixed seed.
+func Scaling[T any](b *testing.B, gen Generator, part func(string) T, want Growth, sizes ...int) {
+	b.Helper()
+	if len(sizes) == 0 {
+		sizes = DefaultSizes
+	}
+
+	var lengths []int
+	var timings []time.Duration
+	for _, size := range sizes {
+		input := gen(rand.New(rand.NewSource(int64(size))), size)
+		lengths = append(lengths, len(input))
+		var perRun time.Duration
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.SetBytes(int64(len(input)))
+			for i := 0; i < b.N; i++ {
+				part(input)
+			}
+			perRun = b.Elapsed() / time.Duration(b.N)
+		})
+		if perRun == 0 {
+			// filtered out by -bench
+			return
+		}
+		timings = append(timings, perRun)
+	}
+
+	b.Log("\n" + plot(sizes, lengths, timings))
+	if len(timings) < 2 {
+		return
+	}
+	got := fitExponent(lengths, timings)
+	b.Logf("fitted over every size: length^%.2f", got)
+	if got > float64(want)+tolerance {
+		b.Errorf("runtime grows like length^%.2f from size %d to %d, want about length^%g", got, sizes[0], sizes[len(timings)-1], float64(want))
+	}
+}
+
+// fitExponent is the k in time = c*length^k that fits the measurements best,
+// by least squares on their logs, so one noisy size can't decide it alone
+func fitExponent(lengths []int, timings []time.Duration) float64 {
+	var sumX, sumY, sumXX, sumXY float64
+	for i := range timings {
+		x, y := math.Log(float64(lengths[i])), math.Log(float64(timings[i]))
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+	}
+	n := float64(len(timings))
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
+
+// exponent is the k in time = c*length^k that fits two measurements
+func exponent(length1, length2 int, time1, time2 time.Duration) float64 {
+	return math.Log(float64(time2)/float64(time1)) / math.Log(float64(length2)/float64(length1))
+}
+
+// plot draws a bar per size, scaled to the slowest, with the growth exponent
+// from the input before
+func plot(sizes, lengths []int, timings []time.Duration) string {
+	const width = 40
+	slowest := timings[0]
+	for _, t := range timings {
+		slowest = max(slowest, t)
+	}
+
+	var sb strings.Builder
+	for i, t := range timings {
+		bar := max(1, int(float64(width)*float64(t)/float64(slowest)))
+		growth := ""
+		if i > 0 {
+			growth = fmt.Sprintf("length^%.2f", exponent(lengths[i-1], lengths[i], timings[i-1], t))
+		}
+		fmt.Fprintf(&sb, "size %-6d %9dB %12v %-*s %s\n", sizes[i], lengths[i], t, width, strings.Repeat("#", bar), growth)
+	}
+	return sb.String()
+}
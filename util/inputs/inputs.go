@@ -0,0 +1,83 @@
+// Package inputs cleans up puzzle inputs and splits them into lines, blank
+// line separated blocks and named sections. It's called inputs so it doesn't
+// clash with the input variable every day embeds.
+package inputs
+
+import (
+	"strings"
+)
+
+// Normalize converts \r\n and \r line endings to \n, trims trailing
+// whitespace from every line and drops trailing blank lines. Inputs saved on
+// Windows parse the same as ones saved anywhere else.
+func Normalize(input string) string {
+	input = strings.ReplaceAll(input, "\r\n", "\n")
+	input = strings.ReplaceAll(input, "\r", "\n")
+
+	lines := strings.Split(input, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// Lines returns the normalized lines of input.
+func Lines(input string) []string {
+	return strings.Split(Normalize(input), "\n")
+}
+
+// Blocks returns the groups of lines separated by one or more blank lines.
+func Blocks(input string) (blocks []string) {
+	for _, block := range strings.Split(Normalize(input), "\n\n") {
+		// extra blank lines between blocks leave empty or newline only splits
+		block = strings.Trim(block, "\n")
+		if block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// Section is a block that starts with a header, like "seeds: 79 14" or
+// "seed-to-soil map:" followed by lines of numbers.
+type Section struct {
+	Header string
+	Body   string
+}
+
+// Sections splits input into blocks and the blocks into sections. A block's
+// first line is a header when it has a colon at the end of the line or
+// followed by a space. The header is the text before the colon and the body
+// is everything after it. Blocks without a header have an empty Header.
+func Sections(input string) (sections []Section) {
+	for _, block := range Blocks(input) {
+		sections = append(sections, parseSection(block))
+	}
+	return sections
+}
+
+// SectionByHeader returns the first section with the given header.
+func SectionByHeader(sections []Section, header string) (Section, bool) {
+	for _, section := range sections {
+		if section.Header == header {
+			return section, true
+		}
+	}
+	return Section{}, false
+}
+
+func parseSection(block string) Section {
+	firstLine, rest, _ := strings.Cut(block, "\n")
+
+	if header, ok := strings.CutSuffix(firstLine, ":"); ok {
+		return Section{header, rest}
+	}
+	if header, afterHeader, ok := strings.Cut(firstLine, ": "); ok {
+		body := strings.TrimSpace(afterHeader)
+		if rest != "" {
+			body += "\n" + rest
+		}
+		return Section{header, body}
+	}
+	return Section{"", block}
+}
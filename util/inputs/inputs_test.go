@@ -0,0 +1,61 @@
+package inputs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var almanac = "seeds: 79 14 55 13\r\n\r\nseed-to-soil map:\r\n50 98 2  \r\n52 50 48\r\n\r\n\r\nsoil-to-fertilizer map:\r\n0 15 37\r\n\r\n"
+
+func TestNormalize(t *testing.T) {
+	if got, want := Normalize("a \r\nb\t\rc\n\n"), "a\nb\nc"; got != want {
+		t.Errorf("Normalize() = %q, want %q", got, want)
+	}
+	if got, want := Lines("1\r\n2\r\n"), []string{"1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines() = %q, want %q", got, want)
+	}
+}
+
+func TestSections(t *testing.T) {
+	want := []Section{
+		{"seeds", "79 14 55 13"},
+		{"seed-to-soil map", "50 98 2\n52 50 48"},
+		{"soil-to-fertilizer map", "0 15 37"},
+	}
+	if got := Sections(almanac); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sections() = %q, want %q", got, want)
+	}
+
+	if got, _ := SectionByHeader(Sections(almanac), "seed-to-soil map"); got != want[1] {
+		t.Errorf("SectionByHeader() = %q, want %q", got, want[1])
+	}
+
+	grids := "#.#\n..#\n\n##.\n#.."
+	if got := Sections(grids); got[0] != (Section{"", "#.#\n..#"}) || len(got) != 2 {
+		t.Errorf("Sections() = %q, want blocks without headers", got)
+	}
+}
+
+func TestScanner(t *testing.T) {
+	scanner := NewScanner(strings.NewReader(almanac))
+	var got []Section
+	for scanner.ScanBlock() {
+		got = append(got, scanner.Section())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if want := Sections(almanac); !reflect.DeepEqual(got, want) {
+		t.Errorf("Scanner sections = %q, want %q", got, want)
+	}
+
+	scanner = NewScanner(strings.NewReader("a\rb \r\nc"))
+	var lines []string
+	for scanner.ScanLine() {
+		lines = append(lines, scanner.Line())
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("Scanner lines = %q, want %q", lines, want)
+	}
+}
@@ -0,0 +1,122 @@
+package inputs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
+)
+
+// Dir is the directory of named inputs in a day. Each input is NAME.txt, or
+// NAME.txt.enc from the vault, with its expected answers next to it in
+// NAME.answers:
+//
+//	part1: 6842
+//	part2: 11004
+//
+// Either part can be left out while it's unsolved. Inputs that aren't
+// anyone's puzzle input, like ones made by a generator, are committed in
+// plain text as generated-NAME.txt.
+const Dir = "inputs"
+
+// Named is one named input and its expected answers.
+type Named struct {
+	Name  string
+	Input string
+	// Want is the answer for part 1 and 2, "" if there isn't one
+	Want [2]string
+	// Locked is set when the input is encrypted and there's no key
+	Locked bool
+}
+
+// LoadNamed reads every input in fsys's inputs/ directory, sorted by name. A
+// day without the directory has no inputs.
+func LoadNamed(fsys fs.FS) ([]Named, error) {
+	entries, err := fs.ReadDir(fsys, Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(strings.TrimSuffix(entry.Name(), vault.Ext), ".txt"); ok {
+			names[name] = true
+		}
+	}
+
+	var loaded []Named
+	for name := range names {
+		in := Named{Name: name}
+
+		raw, err := vault.ReadFile(fsys, path.Join(Dir, name+".txt"))
+		switch {
+		case errors.Is(err, vault.ErrLocked):
+			in.Locked = true
+		case err != nil:
+			return nil, fmt.Errorf("input %s: %w", name, err)
+		default:
+			in.Input = Normalize(raw)
+		}
+
+		in.Want, err = readAnswers(fsys, path.Join(Dir, name+".answers"))
+		if err != nil {
+			return nil, fmt.Errorf("input %s: %w", name, err)
+		}
+		loaded = append(loaded, in)
+	}
+
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].Name < loaded[j].Name })
+	return loaded, nil
+}
+
+// FindNamed returns the input called name from fsys's inputs/ directory.
+func FindNamed(fsys fs.FS, name string) (Named, error) {
+	loaded, err := LoadNamed(fsys)
+	if err != nil {
+		return Named{}, err
+	}
+	for _, in := range loaded {
+		if in.Name == name {
+			return in, nil
+		}
+	}
+	return Named{}, fmt.Errorf("no input %s in %s/", name, Dir)
+}
+
+// readAnswers parses "part1: X" and "part2: Y" lines, a missing file is no
+// answers yet
+func readAnswers(fsys fs.FS, name string) (want [2]string, err error) {
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return want, nil
+	}
+	if err != nil {
+		return want, err
+	}
+
+	for i, line := range Lines(string(data)) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return want, fmt.Errorf("%s line %d: expected part1: or part2:, got %q", name, i+1, line)
+		}
+		switch strings.TrimSpace(key) {
+		case "part1":
+			want[0] = strings.TrimSpace(value)
+		case "part2":
+			want[1] = strings.TrimSpace(value)
+		default:
+			return want, fmt.Errorf("%s line %d: unknown part %q", name, i+1, key)
+		}
+	}
+	return want, nil
+}
@@ -0,0 +1,93 @@
+package inputs
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
+)
+
+func TestLoadNamed(t *testing.T) {
+	t.Setenv(vault.KeyEnv, "")
+	sealed, err := vault.Encrypt([]byte("secret"), "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := fstest.MapFS{
+		"input.txt":            {Data: []byte("ignored")},
+		"inputs/bob.txt":       {Data: []byte("1\r\n2\r\n")},
+		"inputs/bob.answers":   {Data: []byte("part1: 3\n\npart2: 2\n")},
+		"inputs/alice.txt":     {Data: []byte("5\n")},
+		"inputs/carol.txt.enc": {Data: sealed},
+		"inputs/README.md":     {Data: []byte("not an input")},
+	}
+
+	got, err := LoadNamed(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Named{
+		{Name: "alice", Input: "5"},
+		{Name: "bob", Input: "1\n2", Want: [2]string{"3", "2"}},
+		{Name: "carol", Locked: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("LoadNamed() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("LoadNamed()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	t.Setenv(vault.KeyEnv, "hunter2")
+	got, err = LoadNamed(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[2].Locked || got[2].Input != "secret" {
+		t.Errorf("LoadNamed() with key = %+v, want carol decrypted", got[2])
+	}
+}
+
+func TestLoadNamed_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers string
+		wantErr string
+	}{
+		{"no colon", "part1 3", "expected part1: or part2:"},
+		{"unknown part", "part3: 3", "unknown part"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := fstest.MapFS{
+				"inputs/a.txt":     {Data: []byte("1")},
+				"inputs/a.answers": {Data: []byte(tt.answers)},
+			}
+			_, err := LoadNamed(files)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadNamed() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got, err := LoadNamed(fstest.MapFS{"input.txt": {Data: []byte("1")}}); err != nil || got != nil {
+		t.Errorf("LoadNamed() without inputs/ = %v, %v, want nothing", got, err)
+	}
+}
+
+func TestFindNamed(t *testing.T) {
+	files := fstest.MapFS{
+		"inputs/alice.txt":     {Data: []byte("5\n")},
+		"inputs/alice.answers": {Data: []byte("part1: 5\n")},
+	}
+	if got, err := FindNamed(files, "alice"); err != nil || got.Input != "5" || got.Want[0] != "5" {
+		t.Errorf("FindNamed(alice) = %+v, %v", got, err)
+	}
+	if _, err := FindNamed(files, "bob"); err == nil {
+		t.Error("FindNamed(bob) expected an error")
+	}
+}
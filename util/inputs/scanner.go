@@ -0,0 +1,106 @@
+package inputs
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// maxLineLength is how long a single line can be, some inputs are one line
+const maxLineLength = 1024 * 1024
+
+// Scanner reads normalized lines or blocks from a reader one at a time, for
+// inputs too big to hold as a string. Use either ScanLine or ScanBlock, they
+// share the same reader:
+//
+//	scanner := inputs.NewScanner(file)
+//	for scanner.ScanBlock() {
+//		fmt.Println(scanner.Block())
+//	}
+//	if err := scanner.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+type Scanner struct {
+	lines *bufio.Scanner
+	line  string
+	block []string
+}
+
+func NewScanner(r io.Reader) *Scanner {
+	lines := bufio.NewScanner(r)
+	lines.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	lines.Split(scanLines)
+	return &Scanner{lines: lines}
+}
+
+// ScanLine advances to the next line, returning false at the end of input or
+// on an error.
+func (s *Scanner) ScanLine() bool {
+	if !s.lines.Scan() {
+		return false
+	}
+	s.line = strings.TrimRight(s.lines.Text(), " \t")
+	return true
+}
+
+// Line returns the line read by the last ScanLine.
+func (s *Scanner) Line() string {
+	return s.line
+}
+
+// ScanBlock advances to the next group of lines separated by blank lines,
+// returning false at the end of input or on an error.
+func (s *Scanner) ScanBlock() bool {
+	s.block = nil
+	for s.ScanLine() {
+		if s.line == "" {
+			if len(s.block) > 0 {
+				return true
+			}
+			continue
+		}
+		s.block = append(s.block, s.line)
+	}
+	return len(s.block) > 0
+}
+
+// Block returns the lines of the block read by the last ScanBlock.
+func (s *Scanner) Block() []string {
+	return s.block
+}
+
+// Section returns the block read by the last ScanBlock as a section.
+func (s *Scanner) Section() Section {
+	return parseSection(strings.Join(s.block, "\n"))
+}
+
+// Err returns the first error from the reader.
+func (s *Scanner) Err() error {
+	return s.lines.Err()
+}
+
+// scanLines is bufio.ScanLines that also ends lines on a lone \r
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	for i, b := range data {
+		switch b {
+		case '\n':
+			return i + 1, data[:i], nil
+		case '\r':
+			if i+1 < len(data) {
+				if data[i+1] == '\n' {
+					return i + 2, data[:i], nil
+				}
+				return i + 1, data[:i], nil
+			}
+			// need the next byte to tell \r\n from \r
+			if atEOF {
+				return i + 1, data[:i], nil
+			}
+			return 0, nil, nil
+		}
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,83 @@
+package maths
+
+import (
+	"math"
+	"math/big"
+)
+
+// float64 represents every integer exactly up to 2^53, keep a margin below it
+const maxExactFloat = 1 << 52
+
+// ISqrt returns the integer square root of n, the largest r where r*r <= n.
+// Small values go through math.Sqrt, anything that float64 can't represent
+// exactly falls back to big.Int.
+func ISqrt(n int64) int64 {
+	if n < 0 {
+		panic("ISqrt of negative number")
+	}
+	if n >= maxExactFloat {
+		return ISqrtBig(big.NewInt(n)).Int64()
+	}
+
+	r := int64(math.Sqrt(float64(n)))
+	for r*r > n {
+		r--
+	}
+	for (r+1)*(r+1) <= n {
+		r++
+	}
+	return r
+}
+
+// ISqrtBig is ISqrt for values of any size.
+func ISqrtBig(n *big.Int) *big.Int {
+	return new(big.Int).Sqrt(n)
+}
+
+// QuadraticPositiveRange returns the inclusive range of integers x where
+// a*x^2 + b*x + c > 0. a must be negative so the range is bounded, ok is false
+// when no integer makes the quadratic positive.
+func QuadraticPositiveRange(a, b, c int64) (lo, hi int64, ok bool) {
+	if a >= 0 {
+		panic("QuadraticPositiveRange: a must be negative")
+	}
+	A, B, C := big.NewInt(a), big.NewInt(b), big.NewInt(c)
+
+	// disc = b^2 - 4ac, a touching or missing parabola is never positive
+	disc := new(big.Int).Mul(B, B)
+	disc.Sub(disc, new(big.Int).Mul(big.NewInt(4), new(big.Int).Mul(A, C)))
+	if disc.Sign() <= 0 {
+		return 0, 0, false
+	}
+
+	// with a < 0 the roots are (b -/+ sqrt(disc)) / -2a, rounded here and
+	// nudged onto the exact boundary below
+	sqrtDisc := ISqrtBig(disc)
+	twoNegA := big.NewInt(-2 * a)
+	lo = new(big.Int).Div(new(big.Int).Sub(B, sqrtDisc), twoNegA).Int64()
+	hi = new(big.Int).Div(new(big.Int).Add(B, sqrtDisc), twoNegA).Int64()
+
+	isPositive := func(x int64) bool {
+		X := big.NewInt(x)
+		y := new(big.Int).Mul(A, X)
+		y.Add(y, B)
+		y.Mul(y, X)
+		y.Add(y, C)
+		return y.Sign() > 0
+	}
+
+	for !isPositive(lo) && lo <= hi {
+		lo++
+	}
+	for isPositive(lo - 1) {
+		lo--
+	}
+	for !isPositive(hi) && hi >= lo {
+		hi--
+	}
+	for isPositive(hi + 1) {
+		hi++
+	}
+
+	return lo, hi, lo <= hi
+}
@@ -0,0 +1,42 @@
+package maths
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestISqrt(t *testing.T) {
+	tests := []int64{0, 1, 2, 3, 4, 15, 16, 17, 1<<52 - 1, 1 << 52, 1<<62 + 12345, 9223372030926249001, 1<<63 - 1}
+	for _, n := range tests {
+		r := big.NewInt(ISqrt(n))
+		lower := new(big.Int).Mul(r, r)
+		next := new(big.Int).Add(r, big.NewInt(1))
+		upper := new(big.Int).Mul(next, next)
+		if lower.Cmp(big.NewInt(n)) > 0 || upper.Cmp(big.NewInt(n)) <= 0 {
+			t.Errorf("ISqrt(%d) = %v", n, r)
+		}
+	}
+}
+
+func TestQuadraticPositiveRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c int64
+		lo, hi  int64
+		ok      bool
+	}{
+		{"race example", -1, 7, -9, 2, 5, true},
+		{"integer roots are excluded", -1, 30, -200, 11, 19, true},
+		{"touching", -1, 2, -1, 0, 0, false},
+		{"between integers", -4, 4, 0, 0, 0, false},
+		{"negative xs", -1, -10, -21, -6, -4, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, ok := QuadraticPositiveRange(tt.a, tt.b, tt.c)
+			if ok != tt.ok || (ok && (lo != tt.lo || hi != tt.hi)) {
+				t.Errorf("QuadraticPositiveRange() = %v, %v, %v, want %v, %v, %v", lo, hi, ok, tt.lo, tt.hi, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package maths
+
+import (
+	"math"
+	"math/big"
+)
+
+// DifferenceTable returns the finite differences of seq, starting with seq
+// itself and stopping at the first row that is all zeros or has one value.
+// Values are kept as rationals so nothing is lost on the way down.
+func DifferenceTable(seq []int) [][]*big.Rat {
+	row := make([]*big.Rat, len(seq))
+	for i, v := range seq {
+		row[i] = big.NewRat(int64(v), 1)
+	}
+
+	table := [][]*big.Rat{row}
+	for len(row) > 1 && !allZero(row) {
+		next := make([]*big.Rat, len(row)-1)
+		for i := range next {
+			next[i] = new(big.Rat).Sub(row[i+1], row[i])
+		}
+		table = append(table, next)
+		row = next
+	}
+	return table
+}
+
+// PolynomialDegree returns the degree of the polynomial that generates seq.
+// ok is false when seq is too short for the differences to reach a row of
+// zeros, so the degree can't be confirmed. An all zero seq has degree -1.
+func PolynomialDegree(seq []int) (degree int, ok bool) {
+	table := DifferenceTable(seq)
+	last := table[len(table)-1]
+	if !allZero(last) {
+		return len(table) - 1, false
+	}
+	return len(table) - 2, true
+}
+
+// Extrapolate returns the value k steps after the last element of seq, using
+// the lowest degree polynomial that passes through every element. seq must
+// not be empty, and the value must fit in an int.
+func Extrapolate(seq []int, k int) int {
+	return ratToInt(ExtrapolateRat(seq, len(seq)-1+k))
+}
+
+// ExtrapolateBackward returns the value k steps before the first element of seq.
+func ExtrapolateBackward(seq []int, k int) int {
+	return ratToInt(ExtrapolateRat(seq, -k))
+}
+
+// ExtrapolateRat evaluates the sequence's polynomial at index x, where seq[0]
+// is index 0. It panics if seq is empty, there's no polynomial to evaluate.
+// It uses Newton's forward difference formula:
+//
+//	f(x) = sum of Δʲf(0) * C(x, j)
+func ExtrapolateRat(seq []int, x int) *big.Rat {
+	if len(seq) == 0 {
+		panic("ExtrapolateRat: empty sequence")
+	}
+	table := DifferenceTable(seq)
+
+	total := new(big.Rat)
+	binom := big.NewRat(1, 1) // C(x, 0)
+	for j, row := range table {
+		if j > 0 {
+			// C(x, j) = C(x, j-1) * (x-j+1) / j
+			binom.Mul(binom, big.NewRat(int64(x-j+1), int64(j)))
+		}
+		total.Add(total, new(big.Rat).Mul(row[0], binom))
+	}
+	return total
+}
+
+// Lagrange evaluates the polynomial through the points (xs[i], ys[i]) at x.
+// The xs don't need to be evenly spaced, but must be distinct.
+func Lagrange(xs, ys []int, x int) *big.Rat {
+	if len(xs) != len(ys) {
+		panic("Lagrange: xs and ys have different lengths")
+	}
+
+	total := new(big.Rat)
+	for i := range xs {
+		term := big.NewRat(int64(ys[i]), 1)
+		for j := range xs {
+			if i == j {
+				continue
+			}
+			if xs[i] == xs[j] {
+				panic("Lagrange: duplicate x value")
+			}
+			term.Mul(term, big.NewRat(int64(x-xs[j]), int64(xs[i]-xs[j])))
+		}
+		total.Add(total, term)
+	}
+	return total
+}
+
+// LagrangeInt is Lagrange for polynomials that are known to land on an integer.
+func LagrangeInt(xs, ys []int, x int) int {
+	return ratToInt(Lagrange(xs, ys, x))
+}
+
+func allZero(row []*big.Rat) bool {
+	for _, v := range row {
+		if v.Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ratToInt panics unless r is a whole number that fits in an int, rather
+// than truncating it
+func ratToInt(r *big.Rat) int {
+	if !r.IsInt() {
+		panic("expected an integer result, got " + r.RatString())
+	}
+	n := r.Num()
+	if !n.IsInt64() || n.Int64() < math.MinInt || n.Int64() > math.MaxInt {
+		panic("integer result overflows int: " + n.String())
+	}
+	return int(n.Int64())
+}
@@ -0,0 +1,92 @@
+package maths
+
+import "testing"
+
+func TestExtrapolate(t *testing.T) {
+	tests := []struct {
+		name     string
+		seq      []int
+		k        int
+		want     int
+		wantBack int
+	}{
+		{"linear", []int{0, 3, 6, 9, 12, 15}, 1, 18, -3},
+		{"quadratic", []int{1, 3, 6, 10, 15, 21}, 1, 28, 0},
+		{"cubic", []int{10, 13, 16, 21, 30, 45}, 1, 68, 5},
+		{"several steps", []int{1, 4, 9, 16}, 3, 49, 4},
+		{"constant", []int{7, 7, 7}, 5, 7, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Extrapolate(tt.seq, tt.k); got != tt.want {
+				t.Errorf("Extrapolate() = %v, want %v", got, tt.want)
+			}
+			if got := ExtrapolateBackward(tt.seq, tt.k); got != tt.wantBack {
+				t.Errorf("ExtrapolateBackward() = %v, want %v", got, tt.wantBack)
+			}
+		})
+	}
+}
+
+func TestPolynomialDegree(t *testing.T) {
+	tests := []struct {
+		name   string
+		seq    []int
+		want   int
+		wantOk bool
+	}{
+		{"zeros", []int{0, 0, 0}, -1, true},
+		{"constant", []int{4, 4}, 0, true},
+		{"quadratic", []int{1, 3, 6, 10, 15}, 2, true},
+		{"too short", []int{1, 4, 9}, 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := PolynomialDegree(tt.seq)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("PolynomialDegree() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestLagrange(t *testing.T) {
+	// y = 2x^2 - 3x + 1 sampled at uneven points
+	xs := []int{65, 196, 327}
+	ys := make([]int, len(xs))
+	for i, x := range xs {
+		ys[i] = 2*x*x - 3*x + 1
+	}
+
+	x := 26501365
+	if got, want := LagrangeInt(xs, ys, x), 2*x*x-3*x+1; got != want {
+		t.Errorf("LagrangeInt() = %v, want %v", got, want)
+	}
+
+	if got := Lagrange([]int{0, 2}, []int{0, 1}, 1).RatString(); got != "1/2" {
+		t.Errorf("Lagrange() = %v, want 1/2", got)
+	}
+}
+
+func TestSequence_panics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{"empty sequence", func() { Extrapolate(nil, 1) }, "ExtrapolateRat: empty sequence"},
+		{"not an integer", func() { LagrangeInt([]int{0, 2}, []int{0, 1}, 1) }, "expected an integer result, got 1/2"},
+		// the line through 0 and 2^62 reaches 2^63 next, one past the largest int64
+		{"overflow", func() { Extrapolate([]int{0, 1 << 62}, 1) }, "integer result overflows int: 9223372036854775808"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if got := recover(); got != tt.want {
+					t.Errorf("panicked with %v, want %q", got, tt.want)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+)
+
+// runAll runs the part once on every named input in fsys and reports the
+// inputs it failed on, without profiling or copying any answers
+func runAll(fsys fs.FS, opts *Options, solveOn func(input string) func(context.Context) any) error {
+	loaded, err := inputs.LoadNamed(fsys)
+	if err != nil {
+		return err
+	}
+	if len(loaded) == 0 {
+		return fmt.Errorf("no named inputs in %s/", inputs.Dir)
+	}
+
+	fmt.Printf("Running part %d on %d inputs\n", opts.Part, len(loaded))
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	var failed []string
+	for _, in := range loaded {
+		want := in.Want[opts.Part-1]
+		if in.Locked {
+			fmt.Fprintf(tw, "%s\t\tlocked\t\n", in.Name)
+			continue
+		}
+
+		start := time.Now()
+		ans, err := within(opts.Timeout, solveOn(in.Input))
+		took := time.Since(start)
+		var text string
+		if err == nil {
+			text, err = answerText(ans)
+		}
+
+		var result string
+		switch {
+		case errors.Is(err, ErrTimeout):
+			result = fmt.Sprintf("timed out after %v", opts.Timeout)
+		case err != nil:
+			// the rest is a drawing or a stack, too long for the table
+			result, _, _ = strings.Cut(err.Error(), "\n")
+		case want == "":
+			result = "no answer to check"
+		case text != want:
+			result = "FAIL, want " + want
+		default:
+			result = "ok"
+		}
+		if err != nil || (want != "" && text != want) {
+			failed = append(failed, in.Name)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%v\n", in.Name, text, result, took)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("part %d failed on %s", opts.Part, strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+)
+
+const memProfileRate = 4096
+
+// profiler writes the profiles opts asks for while a part runs
+type profiler struct {
+	opts *Options
+	// base is the profiles' path without extension, like
+	// .aoc/profiles/2023-day14-part2
+	base       string
+	cpu, trace *os.File
+}
+
+func newProfiler(dir string, opts *Options) *profiler {
+	name := fmt.Sprintf("%s-%s-part%d", filepath.Base(filepath.Dir(dir)), filepath.Base(dir), opts.Part)
+	return &profiler{opts: opts, base: filepath.Join(dir, "../..", Dir, name)}
+}
+
+func (p *profiler) cpuPath() string   { return p.base + ".cpu.pprof" }
+func (p *profiler) memPath() string   { return p.base + ".mem.pprof" }
+func (p *profiler) tracePath() string { return p.base + ".trace" }
+
+func (p *profiler) start() error {
+	if !p.opts.CPUProfile && !p.opts.MemProfile && !p.opts.Trace {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(p.base), 0o755); err != nil {
+		return err
+	}
+
+	if p.opts.MemProfile {
+		// the default samples every 512KiB, which misses most of a fast part
+		runtime.MemProfileRate = memProfileRate
+	}
+	var err error
+	if p.opts.CPUProfile {
+		if p.cpu, err = os.Create(p.cpuPath()); err != nil {
+			return err
+		}
+		if err := pprof.StartCPUProfile(p.cpu); err != nil {
+			return fmt.Errorf("starting CPU profile: %w", err)
+		}
+	}
+	if p.opts.Trace {
+		if p.trace, err = os.Create(p.tracePath()); err != nil {
+			return err
+		}
+		if err := trace.Start(p.trace); err != nil {
+			return fmt.Errorf("starting trace: %w", err)
+		}
+	}
+	return nil
+}
+
+// stop finishes the CPU profile and trace and writes the memory profile,
+// which has every allocation since the program started
+func (p *profiler) stop() error {
+	if p.cpu != nil {
+		pprof.StopCPUProfile()
+		if err := p.cpu.Close(); err != nil {
+			return err
+		}
+	}
+	if p.trace != nil {
+		trace.Stop()
+		if err := p.trace.Close(); err != nil {
+			return err
+		}
+	}
+	if p.opts.MemProfile {
+		f, err := os.Create(p.memPath())
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		runtime.GC()
+		if err := pprof.Lookup("allocs").WriteTo(f, 0); err != nil {
+			return fmt.Errorf("writing memory profile: %w", err)
+		}
+		return f.Close()
+	}
+	return nil
+}
+
+// summarize prints where the profiles are and the hottest functions in them
+func (p *profiler) summarize(w io.Writer) {
+	profiles := []struct {
+		enabled bool
+		path    string
+		title   string
+	}{
+		{p.opts.CPUProfile, p.cpuPath(), "CPU time"},
+		{p.opts.MemProfile, p.memPath(), "bytes allocated"},
+	}
+	for _, prof := range profiles {
+		if !prof.enabled {
+			continue
+		}
+		fmt.Fprintf(w, "\nwrote %s\n", prof.path)
+		if p.opts.Top <= 0 {
+			continue
+		}
+		// leave out the profilers' own buffers
+		out, err := exec.Command("go", "tool", "pprof", "-top", "-ignore", `^runtime/pprof\.|\(\*profiler\)\.start`,
+			fmt.Sprintf("-nodecount=%d", p.opts.Top), prof.path).CombinedOutput()
+		if err != nil {
+			fmt.Fprintf(w, "summarizing profile: %v\n%s", err, out)
+			continue
+		}
+		fmt.Fprintf(w, "hottest functions by %s:\n%s", prof.title, topTable(string(out)))
+	}
+	if p.opts.Trace {
+		fmt.Fprintf(w, "\nwrote %s, open it with go tool trace\n", p.tracePath())
+	}
+}
+
+// topTable cuts the header off go tool pprof -top output, down to the line
+// saying how much the nodes shown account for. The header has a Time: line
+// that would be mistaken for the part's.
+func topTable(output string) string {
+	lines := strings.SplitAfter(output, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "Showing nodes") {
+			return strings.Join(lines[i:], "")
+		}
+	}
+	return output
+}
@@ -0,0 +1,296 @@
+// Package runner is the main every day shares: it runs a part on the day's
+// input, prints and times its answer, and can profile it.
+package runner
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+	"github.com/Kris-Pelteshki/aoc_2023/util"
+	"github.com/Kris-Pelteshki/aoc_2023/util/inputs"
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
+)
+
+// A Part solves one half of a day's puzzle. Parts with long loops should
+// check ctx and return early once it's done, their answer is thrown away.
+type Part[T any] func(ctx context.Context, input string) T
+
+// Quick makes a Part of one that's over too fast to need a context.
+func Quick[T any](part func(input string) T) Part[T] {
+	return func(_ context.Context, input string) T {
+		return part(input)
+	}
+}
+
+// Background calls part without a deadline, for tests and benchmarks that
+// take plain functions.
+func Background[T any](part Part[T]) func(input string) T {
+	return func(input string) T {
+		return part(context.Background(), input)
+	}
+}
+
+// ErrTimeout is returned by Run when the part ran out of time.
+var ErrTimeout = errors.New("timed out")
+
+// errNoInput is returned by Run for an empty input, which is what
+// vault.Input gives when the input is locked or missing
+var errNoInput = fmt.Errorf("the input is empty, add input.txt or set %s to decrypt input.txt%s", vault.KeyEnv, vault.Ext)
+
+// Options are the flags every day takes.
+type Options struct {
+	Part int
+	// CPUProfile, MemProfile and Trace write the part's profiles to Dir,
+	// named like 2023-day14-part2.cpu.pprof
+	CPUProfile, MemProfile, Trace bool
+	// Bench runs the part this many times, timing and profiling all of them
+	Bench int
+	// Top is how many of the hottest functions to print from each profile
+	Top int
+	// Timeout gives up on each run of the part after this long, if set
+	Timeout time.Duration
+	// Input runs the part on the named input inputs/NAME.txt instead of
+	// input.txt, or on all of them with AllInputs, checking the answers
+	Input string
+	// DayDir is the day's directory, where inputs/ is and which names its
+	// profiles. The working directory is used if it's empty.
+	DayDir string
+}
+
+// AllInputs is the Input that runs the part on every named input.
+const AllInputs = "all"
+
+// Dir is where profiles are written, under the repo root.
+var Dir = filepath.Join(".aoc", "profiles")
+
+// Flags registers the shared flags on the command line, for days with flags
+// of their own to add theirs before calling flag.Parse and Run.
+func Flags() *Options {
+	opts := &Options{Part: 1}
+	flag.Var((*partFlag)(&opts.Part), "part", "part 1 or 2")
+	flag.BoolVar(&opts.CPUProfile, "cpuprofile", false, "write a CPU profile of the part to "+Dir)
+	flag.BoolVar(&opts.MemProfile, "memprofile", false, "write a memory profile of the part to "+Dir)
+	flag.BoolVar(&opts.Trace, "trace", false, "write an execution trace of the part to "+Dir)
+	flag.IntVar(&opts.Bench, "bench", 1, "run the part this many times, profiling all of them")
+	flag.IntVar(&opts.Top, "top", 10, "how many hot functions to summarize from each profile")
+	flag.DurationVar(&opts.Timeout, "timeout", 0, "give up on the part after this long, 0 to never")
+	flag.StringVar(&opts.Input, "input", "", "run on "+inputs.Dir+"/NAME.txt instead of input.txt, or "+AllInputs+" to check every named input")
+	flag.StringVar(&opts.DayDir, "dir", "", "the day's directory, if it isn't the working directory")
+	return opts
+}
+
+// partFlag is -part, which only takes the parts there are
+type partFlag int
+
+func (p *partFlag) String() string { return strconv.Itoa(int(*p)) }
+
+func (p *partFlag) Set(s string) error {
+	part, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	if err := checkPart(part); err != nil {
+		return err
+	}
+	*p = partFlag(part)
+	return nil
+}
+
+// checkPart is an error unless part is 1 or 2
+func checkPart(part int) error {
+	if part != 1 && part != 2 {
+		return fmt.Errorf("there's no part %d, only 1 and 2", part)
+	}
+	return nil
+}
+
+// Main parses the shared flags and runs a part, for days without flags of
+// their own:
+//
+//	func main() {
+//		runner.Main(input, runner.Quick(part1), part2)
+//	}
+func Main[T1, T2 any](input string, part1 Part[T1], part2 Part[T2]) {
+	opts := Flags()
+	flag.Parse()
+	if err := Run(opts, input, part1, part2); err != nil {
+		Exit(err)
+	}
+}
+
+// Run runs the part opts picked on input. The answer is printed and copied
+// to the clipboard even if profiling it fails. Parts can return any answer,
+// one that's a multi-line string is read as letters drawn with '#'. If the
+// part times out that's printed instead, and ErrTimeout returned. A part
+// that panics is stopped like one that fails, with the panic returned.
+func Run[T1, T2 any](opts *Options, input string, part1 Part[T1], part2 Part[T2]) error {
+	dir, err := dayDir(opts)
+	if err != nil {
+		return err
+	}
+	return run(dir, opts, input, part1, part2)
+}
+
+// Exit exits with an error from Run, printing it unless it's a timeout,
+// which Run has already printed.
+func Exit(err error) {
+	if !errors.Is(err, ErrTimeout) {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	os.Exit(1)
+}
+
+// dayDir is the absolute path of the day being run, which go run main.go
+// and aoc run from
+func dayDir(opts *Options) (string, error) {
+	if opts.DayDir == "" {
+		return os.Getwd()
+	}
+	return filepath.Abs(opts.DayDir)
+}
+
+func run[T1, T2 any](dir string, opts *Options, input string, part1 Part[T1], part2 Part[T2]) error {
+	if err := checkPart(opts.Part); err != nil {
+		return err
+	}
+	solveOn := func(input string) func(context.Context) any {
+		if opts.Part == 2 {
+			return func(ctx context.Context) any { return part2(ctx, input) }
+		}
+		return func(ctx context.Context) any { return part1(ctx, input) }
+	}
+
+	var want string
+	switch opts.Input {
+	case "":
+	case AllInputs:
+		return runAll(os.DirFS(dir), opts, solveOn)
+	default:
+		named, err := inputs.FindNamed(os.DirFS(dir), opts.Input)
+		if err != nil {
+			return err
+		}
+		if named.Locked {
+			return fmt.Errorf("%s: %w", named.Name, vault.ErrLocked)
+		}
+		input, want = named.Input, named.Want[opts.Part-1]
+	}
+	if input == "" {
+		return errNoInput
+	}
+	solve := solveOn(input)
+
+	fmt.Println("Running part", opts.Part)
+	p := newProfiler(dir, opts)
+	if err := p.start(); err != nil {
+		return err
+	}
+
+	var ans any
+	var total, fastest time.Duration
+	var partErr error
+	runs := max(1, opts.Bench)
+	for i := 0; i < runs; i++ {
+		start := time.Now()
+		ans, partErr = within(opts.Timeout, solve)
+		took := time.Since(start)
+		if partErr != nil {
+			break
+		}
+		total += took
+		if i == 0 || took < fastest {
+			fastest = took
+		}
+	}
+	// profiles of a part that timed out show where it got stuck
+	err := p.stop()
+
+	if errors.Is(partErr, ErrTimeout) {
+		fmt.Println("Timed out after", opts.Timeout)
+	} else if partErr == nil {
+		text, answerErr := answerText(ans)
+		if answerErr != nil {
+			// still worth timing and profiling, the part did finish
+			partErr = answerErr
+		} else {
+			util.CopyToClipboard(text)
+			fmt.Println("Output:", text)
+			if want != "" && text != want {
+				partErr = fmt.Errorf("part %d on %s = %s, want %s", opts.Part, opts.Input, text, want)
+			}
+		}
+		if runs > 1 {
+			fmt.Printf("Runs: %d, fastest %v\n", runs, fastest)
+		}
+		fmt.Println("Time:", total/time.Duration(runs))
+	}
+
+	if err != nil {
+		return err
+	}
+	p.summarize(os.Stdout)
+	return partErr
+}
+
+// answerText is the answer as it's printed and copied. Parts whose answer is
+// drawn in letters can return the drawing, which is read with cast.OCR.
+func answerText(ans any) (string, error) {
+	drawing, ok := ans.(string)
+	if !ok || !strings.Contains(drawing, "\n") {
+		return fmt.Sprint(ans), nil
+	}
+	letters, err := cast.OCR(drawing)
+	if err != nil {
+		return "", fmt.Errorf("reading the letters in the answer: %w\n%s", err, drawing)
+	}
+	return letters, nil
+}
+
+// within runs solve with a context that's done after timeout, or never if
+// it's 0. It stops waiting at the timeout even if solve doesn't check its
+// context, leaving it running in the background. A panic in solve is
+// returned as an error with its stack.
+func within(timeout time.Duration, solve func(context.Context) any) (any, error) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		ans any
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{err: fmt.Errorf("part panicked: %v\n\n%s", r, debug.Stack())}
+			}
+		}()
+		done <- result{ans: solve(ctx)}
+	}()
+	select {
+	case res := <-done:
+		if res.err != nil {
+			return nil, res.err
+		}
+		// a part that noticed the timeout returns whatever it had
+		if ctx.Err() != nil {
+			return nil, ErrTimeout
+		}
+		return res.ans, nil
+	case <-ctx.Done():
+		return nil, ErrTimeout
+	}
+}
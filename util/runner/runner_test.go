@@ -0,0 +1,180 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun_profiles(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "2023", "day14")
+
+	calls := 0
+	part := func(input string) int {
+		calls++
+		return len(strings.Repeat(input, 1000))
+	}
+	opts := &Options{Part: 2, CPUProfile: true, MemProfile: true, Trace: true, Bench: 3}
+	if err := run(dir, opts, "abc", Quick(part), Quick(part)); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("part ran %d times, want 3", calls)
+	}
+
+	for _, name := range []string{"2023-day14-part2.cpu.pprof", "2023-day14-part2.mem.pprof", "2023-day14-part2.trace"} {
+		info, err := os.Stat(filepath.Join(root, Dir, name))
+		if err != nil {
+			t.Error(err)
+		} else if info.Size() == 0 {
+			t.Errorf("%s is empty", name)
+		}
+	}
+}
+
+func TestRun_timeout(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "2023", "day16")
+	quick := Quick(func(input string) int { return len(input) })
+	// checks its context, like the long loops in the days should
+	patient := func(ctx context.Context, input string) int {
+		<-ctx.Done()
+		return 0
+	}
+	// ignores it, so the runner has to stop waiting on its own
+	stuck := Quick(func(input string) int {
+		select {}
+	})
+
+	tests := []struct {
+		name    string
+		part    Part[int]
+		wantErr error
+	}{
+		{"in time", quick, nil},
+		{"checks context", patient, ErrTimeout},
+		{"ignores context", stuck, ErrTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Options{Part: 2, Timeout: 10 * time.Millisecond}
+			if err := run(dir, opts, "abc", quick, tt.part); !errors.Is(err, tt.wantErr) {
+				t.Errorf("run() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRun_panic(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "2023", "day17")
+	quick := Quick(func(input string) int { return len(input) })
+	panics := Quick(func(input string) int { panic("index out of range") })
+
+	opts := &Options{Part: 2, CPUProfile: true}
+	err := run(dir, opts, "abc", quick, panics)
+	if err == nil || !strings.Contains(err.Error(), "part panicked: index out of range") {
+		t.Fatalf("run() = %v, want the panic", err)
+	}
+	// the profile was stopped, so another can start
+	if err := run(dir, opts, "abc", quick, quick); err != nil {
+		t.Errorf("run() after a panic = %v", err)
+	}
+}
+
+func TestTopTable(t *testing.T) {
+	output := "File: day14\nType: cpu\nTime: Dec 14, 2023 at 9:00am (CET)\nDuration: 1.2s\n" +
+		"Showing nodes accounting for 1s, 90% of 1.1s total\n" +
+		"      flat  flat%   sum%        cum   cum%\n" +
+		"        1s 90.00% 90.00%         1s 90.00%  main.platform.cycle\n"
+	want := "Showing nodes accounting for 1s, 90% of 1.1s total\n" +
+		"      flat  flat%   sum%        cum   cum%\n" +
+		"        1s 90.00% 90.00%         1s 90.00%  main.platform.cycle\n"
+	if got := topTable(output); got != want {
+		t.Errorf("topTable() = %q, want %q", got, want)
+	}
+}
+
+func TestAnswerText(t *testing.T) {
+	drawing := "#..#.###.####\n#..#..#.....#\n####..#....#.\n#..#..#...#..\n#..#..#..#...\n#..#.###.####"
+	tests := []struct {
+		name    string
+		ans     any
+		want    string
+		wantErr bool
+	}{
+		{"number", 405, "405", false},
+		{"string", "HIZ", "HIZ", false},
+		{"drawing", drawing, "HIZ", false},
+		{"unreadable drawing", "#.#\n.#.", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := answerText(tt.ans)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("answerText() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_noInput(t *testing.T) {
+	quick := Quick(func(input string) int { return len(input) })
+	opts := &Options{Part: 1}
+	if err := run(t.TempDir(), opts, "", quick, quick); !errors.Is(err, errNoInput) {
+		t.Errorf("run() = %v, want %v", err, errNoInput)
+	}
+}
+
+func TestRun_badPart(t *testing.T) {
+	quick := Quick(func(input string) int { return len(input) })
+	for _, part := range []int{0, 3} {
+		opts := &Options{Part: part}
+		if err := run(t.TempDir(), opts, "abc", quick, quick); err == nil {
+			t.Errorf("run() on part %d = nil, want an error", part)
+		}
+		if err := (*partFlag)(new(int)).Set(fmt.Sprint(part)); err == nil {
+			t.Errorf("-part %d = nil, want an error", part)
+		}
+	}
+}
+
+func TestRun_inputs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "2023", "day08")
+	files := map[string]string{
+		"alice.txt":     "abc",
+		"alice.answers": "part1: 3\npart2: 3",
+		"bob.txt":       "abcd",
+		"bob.answers":   "part1: 4\npart2: 5",
+	}
+	os.MkdirAll(filepath.Join(dir, "inputs"), 0o755)
+	for name, data := range files {
+		os.WriteFile(filepath.Join(dir, "inputs", name), []byte(data), 0o644)
+	}
+	length := Quick(func(input string) int { return len(input) })
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr string
+	}{
+		{"one input", Options{Part: 1, Input: "bob"}, ""},
+		{"wrong answer", Options{Part: 2, Input: "bob"}, "part 2 on bob = 4, want 5"},
+		{"missing input", Options{Part: 1, Input: "carol"}, "no input carol"},
+		{"all pass", Options{Part: 1, Input: AllInputs}, ""},
+		{"all with a failure", Options{Part: 2, Input: AllInputs}, "part 2 failed on bob"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(dir, &tt.opts, "", length, length)
+			if (err == nil) != (tt.wantErr == "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("run() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,408 @@
+// Package scan matches puzzle lines against simple patterns and binds the
+// captured text into variables or struct fields:
+//
+//	p := scan.MustCompile("{node} = ({left}, {right})")
+//	var node, left, right string
+//	err := p.Scan("AAA = (BBB, CCC)", &node, &left, &right)
+//
+// Pattern syntax:
+//   - {name} captures everything up to the next literal text, or to the end
+//     of the line when it's last, so it has to be followed by literal text or
+//     be the end of the pattern
+//   - %d captures an integer with an optional sign
+//   - %s captures a run of non-space characters
+//   - %d and %s have no name, ScanStruct binds them to fields by position
+//   - {{, }} and %% match a literal {, } and %
+//   - anything else has to match exactly
+package scan
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Kris-Pelteshki/aoc_2023/cast"
+)
+
+// Pattern is a compiled pattern, safe to reuse for every line of an input.
+type Pattern struct {
+	source   string
+	segments []segment
+}
+
+type segment struct {
+	// literal is set for text that has to match exactly, otherwise the
+	// segment is a capture
+	literal string
+	name    string
+	verb    byte // 'd', 's' or 0 for {name}
+}
+
+func (s segment) String() string {
+	if s.verb != 0 {
+		return "%" + string(s.verb)
+	}
+	return "{" + s.name + "}"
+}
+
+// Error is returned when a line doesn't match or a capture can't be bound.
+// Its message shows the line with a caret under the column:
+//
+//	line 2, column 1: expected "Game ", found "Gam 2: y"
+//		Gam 2: y
+//		^
+type Error struct {
+	Line   int    // 1 based, 0 when a single line was scanned
+	Column int    // 1 based byte offset into the line
+	Text   string // the line, left out of the message when empty
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	var msg string
+	if e.Line > 0 {
+		msg = fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+	} else {
+		msg = fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+	}
+	if e.Text == "" {
+		return msg
+	}
+	return msg + "\n\t" + e.Text + "\n\t" + caretPadding(e.Text, e.Column-1) + "^"
+}
+
+// caretPadding is the whitespace that lines a caret up under byte col of
+// text, keeping its tabs so it lines up however wide they're shown
+func caretPadding(text string, col int) string {
+	col = min(max(col, 0), len(text))
+	return strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return r
+		}
+		return ' '
+	}, text[:col])
+}
+
+// Compile parses a pattern, see the package docs for the syntax.
+func Compile(pattern string) (*Pattern, error) {
+	p := &Pattern{source: pattern}
+	var literal strings.Builder
+	captures := 0
+
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			p.segments = append(p.segments, segment{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+	addCapture := func(name string, verb byte) {
+		flushLiteral()
+		p.segments = append(p.segments, segment{name: name, verb: verb})
+		captures++
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		hasNext := i+1 < len(pattern)
+
+		switch {
+		case c == '{' && hasNext && pattern[i+1] == '{',
+			c == '}' && hasNext && pattern[i+1] == '}',
+			c == '%' && hasNext && pattern[i+1] == '%':
+			literal.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end == -1 {
+				return nil, fmt.Errorf("pattern %q: unclosed { at %d", pattern, i+1)
+			}
+			name := pattern[i+1 : i+end]
+			if name == "" {
+				return nil, fmt.Errorf("pattern %q: empty {} at %d", pattern, i+1)
+			}
+			addCapture(name, 0)
+			i += end
+		case c == '}':
+			return nil, fmt.Errorf("pattern %q: unmatched } at %d, use }} for a literal", pattern, i+1)
+		case c == '%':
+			if !hasNext || (pattern[i+1] != 'd' && pattern[i+1] != 's') {
+				return nil, fmt.Errorf("pattern %q: unknown verb at %d, expected %%d, %%s or %%%%", pattern, i+1)
+			}
+			addCapture(strconv.Itoa(captures), pattern[i+1])
+			i++
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flushLiteral()
+
+	for i, seg := range p.segments {
+		isNamed := seg.literal == "" && seg.verb == 0
+		if isNamed && i+1 < len(p.segments) && p.segments[i+1].literal == "" {
+			return nil, fmt.Errorf("pattern %q: %s must be followed by literal text", pattern, seg)
+		}
+	}
+
+	return p, nil
+}
+
+// MustCompile is Compile that panics on a bad pattern, for package level vars.
+func MustCompile(pattern string) *Pattern {
+	p, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p *Pattern) String() string {
+	return p.source
+}
+
+type capture struct {
+	segment
+	text  string
+	start int
+}
+
+func (p *Pattern) match(line string) ([]capture, *Error) {
+	var captures []capture
+	pos := 0
+	fail := func(col int, format string, args ...interface{}) ([]capture, *Error) {
+		return nil, &Error{Column: col + 1, Text: line, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	for i, seg := range p.segments {
+		if seg.literal != "" {
+			if !strings.HasPrefix(line[pos:], seg.literal) {
+				return fail(pos, "expected %q, found %q", seg.literal, preview(line[pos:]))
+			}
+			pos += len(seg.literal)
+			continue
+		}
+
+		end := pos
+		switch {
+		case seg.verb == 'd':
+			if end < len(line) && (line[end] == '-' || line[end] == '+') {
+				end++
+			}
+			digitsStart := end
+			for end < len(line) && line[end] >= '0' && line[end] <= '9' {
+				end++
+			}
+			if end == digitsStart {
+				return fail(pos, "expected an integer for %s, found %q", seg, preview(line[pos:]))
+			}
+		case seg.verb == 's':
+			for end < len(line) && !unicode.IsSpace(rune(line[end])) {
+				end++
+			}
+		case i+1 < len(p.segments):
+			next := p.segments[i+1].literal
+			idx := strings.Index(line[pos:], next)
+			if idx == -1 {
+				return fail(pos, "expected %q after %s, found %q", next, seg, preview(line[pos:]))
+			}
+			end = pos + idx
+		default:
+			end = len(line)
+		}
+
+		if end == pos {
+			return fail(pos, "empty %s", seg)
+		}
+		captures = append(captures, capture{seg, line[pos:end], pos})
+		pos = end
+	}
+
+	if pos != len(line) {
+		return fail(pos, "unexpected trailing text %q", preview(line[pos:]))
+	}
+	return captures, nil
+}
+
+// Match returns the captured text of a line in pattern order.
+func (p *Pattern) Match(line string) ([]string, error) {
+	captures, err := p.match(line)
+	if err != nil {
+		return nil, err
+	}
+	texts := make([]string, len(captures))
+	for i, c := range captures {
+		texts[i] = c.text
+	}
+	return texts, nil
+}
+
+// Scan matches line and stores the captures in order into dests, which must be
+// pointers to strings, integers, floats, or slices of strings or integers.
+// Integer slices get every integer in the capture, string slices its words
+// split on commas and whitespace.
+func (p *Pattern) Scan(line string, dests ...interface{}) error {
+	captures, err := p.match(line)
+	if err != nil {
+		return err
+	}
+	if len(dests) != len(captures) {
+		return fmt.Errorf("pattern %q has %d captures, got %d destinations", p.source, len(captures), len(dests))
+	}
+
+	for i, c := range captures {
+		dest := reflect.ValueOf(dests[i])
+		if dest.Kind() != reflect.Pointer || dest.IsNil() {
+			return fmt.Errorf("destination %d for %s is not a pointer", i, c.segment)
+		}
+		if err := bind(dest.Elem(), c); err != nil {
+			err.Text = line
+			return err
+		}
+	}
+	return nil
+}
+
+// ScanStruct matches line and stores each {name} capture into the field of
+// dest with a `scan:"name"` tag, or else the field with the same name ignoring
+// case. %d and %s captures go into the field at their position: the third
+// capture of the pattern into the third exported field, leaving out fields
+// tagged scan:"-". dest must be a pointer to a struct.
+func (p *Pattern) ScanStruct(line string, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ScanStruct destination must be a pointer to a struct, got %T", dest)
+	}
+	v = v.Elem()
+
+	captures, err := p.match(line)
+	if err != nil {
+		return err
+	}
+
+	for i, c := range captures {
+		field, ok := fieldFor(v, c.segment, i)
+		if !ok {
+			return fmt.Errorf("no field in %s for %s", v.Type(), c.segment)
+		}
+		if err := bind(field, c); err != nil {
+			err.Text = line
+			return err
+		}
+	}
+	return nil
+}
+
+// ScanLines runs ScanStruct on every line of input. Errors include the line
+// number they happened on.
+func ScanLines[T any](p *Pattern, input string) ([]T, error) {
+	lines := strings.Split(input, "\n")
+	results := make([]T, len(lines))
+
+	for i, line := range lines {
+		if err := p.ScanStruct(line, &results[i]); err != nil {
+			if scanErr, ok := err.(*Error); ok {
+				scanErr.Line = i + 1
+				return nil, scanErr
+			}
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+	return results, nil
+}
+
+func fieldFor(v reflect.Value, seg segment, position int) (reflect.Value, bool) {
+	t := v.Type()
+	if seg.verb != 0 {
+		for i := 0; i < t.NumField(); i++ {
+			if skipField(t.Field(i)) {
+				continue
+			}
+			if position == 0 {
+				return v.Field(i), true
+			}
+			position--
+		}
+		return reflect.Value{}, false
+	}
+
+	name := seg.name
+	for i := 0; i < t.NumField(); i++ {
+		if tag, ok := t.Field(i).Tag.Lookup("scan"); ok && tag == name {
+			return v.Field(i), true
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() && strings.EqualFold(t.Field(i).Name, name) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func bind(v reflect.Value, c capture) *Error {
+	if err := setValue(v, c.text); err != nil {
+		return &Error{Column: c.start + 1, Msg: fmt.Sprintf("%s: %s", c.segment, err)}
+	}
+	return nil
+}
+
+func setValue(v reflect.Value, text string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := cast.Parse[int64](text)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(n) {
+			return fmt.Errorf("%d overflows %s", n, v.Type())
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := cast.Parse[uint64](text)
+		if err != nil {
+			return err
+		}
+		if v.OverflowUint(n) {
+			return fmt.Errorf("%d overflows %s", n, v.Type())
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := cast.Parse[float64](text)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			words := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+			slice := reflect.MakeSlice(v.Type(), len(words), len(words))
+			for i, word := range words {
+				slice.Index(i).SetString(word)
+			}
+			v.Set(slice)
+			return nil
+		}
+		nums := cast.ExtractInts(text)
+		slice := reflect.MakeSlice(v.Type(), len(nums), len(nums))
+		for i, n := range nums {
+			if err := setValue(slice.Index(i), strconv.Itoa(n)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// preview shortens the rest of a line for error messages
+func preview(s string) string {
+	if len(s) > 20 {
+		return s[:20] + "..."
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package scan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	var node, left, right string
+	err := MustCompile("{node} = ({left}, {right})").Scan("AAA = (BBB, CCC)", &node, &left, &right)
+	if err != nil || node != "AAA" || left != "BBB" || right != "CCC" {
+		t.Errorf("Scan() = %q %q %q, %v", node, left, right, err)
+	}
+
+	var x1, y1, z1, x2, y2, z2 int
+	err = MustCompile("%d,%d,%d~%d,%d,%d").Scan("1,0,-1~1,2,1", &x1, &y1, &z1, &x2, &y2, &z2)
+	if err != nil || x1 != 1 || z1 != -1 || y2 != 2 {
+		t.Errorf("Scan() = %v %v %v %v %v %v, %v", x1, y1, z1, x2, y2, z2, err)
+	}
+
+	var dir string
+	var steps uint8
+	var color string
+	err = MustCompile("%s %d (#{color})").Scan("R 6 (#70c710)", &dir, &steps, &color)
+	if err != nil || dir != "R" || steps != 6 || color != "70c710" {
+		t.Errorf("Scan() = %v %v %v, %v", dir, steps, color, err)
+	}
+}
+
+func TestScanStruct(t *testing.T) {
+	type workflow struct {
+		Name  string
+		Rules string `scan:"rules"`
+	}
+	var w workflow
+	err := MustCompile("{name}{{{rules}}}").ScanStruct("px{a<2006:qkq,m>2090:A,rfg}", &w)
+	if err != nil || w.Name != "px" || w.Rules != "a<2006:qkq,m>2090:A,rfg" {
+		t.Errorf("ScanStruct() = %+v, %v", w, err)
+	}
+
+	type row struct {
+		Springs string
+		Groups  []int
+	}
+	rows, err := ScanLines[row](MustCompile("{springs} {groups}"), "???.### 1,1,3\n.??..??...?##. 1,1,3")
+	want := []row{{"???.###", []int{1, 1, 3}}, {".??..??...?##.", []int{1, 1, 3}}}
+	if err != nil || !reflect.DeepEqual(rows, want) {
+		t.Errorf("ScanLines() = %+v, %v", rows, err)
+	}
+
+	type module struct {
+		Name         string
+		Destinations []string
+	}
+	var m module
+	err = MustCompile("{name} -> {destinations}").ScanStruct("broadcaster -> a, b, c", &m)
+	if err != nil || m.Name != "broadcaster" || !reflect.DeepEqual(m.Destinations, []string{"a", "b", "c"}) {
+		t.Errorf("ScanStruct() = %+v, %v", m, err)
+	}
+
+	// %d and %s go to fields by position, {color} by name
+	type plan struct {
+		Dir   string
+		Steps int
+		skip  bool
+		Color string
+	}
+	var pl plan
+	err = MustCompile("%s %d (#{color})").ScanStruct("R 6 (#70c710)", &pl)
+	if err != nil || pl != (plan{Dir: "R", Steps: 6, Color: "70c710"}) {
+		t.Errorf("ScanStruct() = %+v, %v", pl, err)
+	}
+
+	type brick struct {
+		X1, Y1, Z1, X2, Y2, Z2 int
+	}
+	var b brick
+	err = MustCompile("%d,%d,%d~%d,%d,%d").ScanStruct("1,0,1~1,2,1", &b)
+	if err != nil || b != (brick{1, 0, 1, 1, 2, 1}) {
+		t.Errorf("ScanStruct() = %+v, %v", b, err)
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	type game struct {
+		ID   int
+		Rest string
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"bad literal", "Game 1: x\nGam 2: y", "line 2, column 1: expected \"Game \", found \"Gam 2: y\"\n\tGam 2: y\n\t^"},
+		{"bad int", "Game 1: x\nGame 2: y\nGame x: z", "line 3, column 6: {id}: parsing \"x\" as int64: strconv.ParseInt: parsing \"x\": invalid syntax\n\tGame x: z\n\t     ^"},
+		{"missing literal", "Game 1 x", "line 1, column 6: expected \": \" after {id}, found \"1 x\"\n\tGame 1 x\n\t     ^"},
+		{"tab", "Game\t1: x", "line 1, column 1: expected \"Game \", found \"Game\\t1: x\"\n\tGame\t1: x\n\t^"},
+	}
+	p := MustCompile("Game {id}: {rest}")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ScanLines[game](p, tt.input)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("ScanLines() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, pattern := range []string{"{a", "a}", "{}", "%x", "{a}{b}", "{a}%d"} {
+		if _, err := Compile(pattern); err == nil {
+			t.Errorf("Compile(%q) expected an error", pattern)
+		}
+	}
+}
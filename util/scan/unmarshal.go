@@ -0,0 +1,200 @@
+package scan
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Unmarshal parses input into dest, a pointer to a slice of structs, one
+// struct per line. Fields are read in order and described with struct tags:
+//
+//   - prefix:"Game" is literal text that has to come before the field
+//   - end:":" ends the field at the next ":", which is then skipped
+//   - sep:";" splits a slice field into elements, sep2, sep3 and so on split
+//     the elements of nested slices
+//
+// A field without end runs to the next whitespace, or to the end of the line
+// when it's the last field. A slice without a sep is split on whitespace.
+// Surrounding whitespace is trimmed from every field and element. Elements
+// can be strings, numbers, slices or structs, which are read the same way as
+// a line. Day02 declares its games like this:
+//
+//	type cubes struct {
+//		Count int
+//		Color string
+//	}
+//	type game struct {
+//		ID    int       `prefix:"Game" end:":"`
+//		Draws [][]cubes `sep:";" sep2:","`
+//	}
+//
+// Only exported fields are read, fields tagged scan:"-" are skipped.
+func Unmarshal(input string, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("Unmarshal destination must be a pointer to a slice, got %T", dest)
+	}
+
+	lines := strings.Split(input, "\n")
+	slice := reflect.MakeSlice(v.Elem().Type(), len(lines), len(lines))
+	for i, line := range lines {
+		if err := decodeValue(slice.Index(i), piece{line, 0}, "", 0); err != nil {
+			err.Line = i + 1
+			err.Text = line
+			return err
+		}
+	}
+
+	v.Elem().Set(slice)
+	return nil
+}
+
+// piece is part of a line and the byte offset it starts at, for errors
+type piece struct {
+	text string
+	col  int
+}
+
+func (p piece) trim() piece {
+	trimmedLeft := strings.TrimLeft(p.text, " \t")
+	return piece{strings.TrimRight(trimmedLeft, " \t"), p.col + len(p.text) - len(trimmedLeft)}
+}
+
+func (p piece) cut(start, end int) piece {
+	return piece{p.text[start:end], p.col + start}
+}
+
+func (p piece) errorf(format string, args ...interface{}) *Error {
+	return &Error{Column: p.col + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func decodeValue(v reflect.Value, p piece, tag reflect.StructTag, depth int) *Error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		return decodeValue(v.Elem(), p, tag, depth)
+	case reflect.Struct:
+		return decodeStruct(v, p)
+	case reflect.Slice:
+		parts := splitList(p, tag.Get(sepKey(depth)))
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := decodeValue(slice.Index(i), part.trim(), tag, depth+1); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	default:
+		if err := setValue(v, p.text); err != nil {
+			return p.errorf("%s", err)
+		}
+		return nil
+	}
+}
+
+func decodeStruct(v reflect.Value, p piece) *Error {
+	t := v.Type()
+	rest := p.trim()
+	last := lastField(t)
+
+	for i := 0; i <= last; i++ {
+		f := t.Field(i)
+		if skipField(f) {
+			continue
+		}
+
+		if prefix := f.Tag.Get("prefix"); prefix != "" {
+			if !strings.HasPrefix(rest.text, prefix) {
+				return rest.errorf("%s: expected %q, found %q", f.Name, prefix, preview(rest.text))
+			}
+			rest = rest.cut(len(prefix), len(rest.text)).trim()
+		}
+
+		var field piece
+		if end, ok := f.Tag.Lookup("end"); ok {
+			idx := strings.Index(rest.text, end)
+			if idx == -1 {
+				return rest.errorf("%s: expected %q after it, found %q", f.Name, end, preview(rest.text))
+			}
+			field = rest.cut(0, idx)
+			rest = rest.cut(idx+len(end), len(rest.text))
+		} else if i == last {
+			field = rest
+			rest = rest.cut(len(rest.text), len(rest.text))
+		} else {
+			idx := strings.IndexAny(rest.text, " \t")
+			if idx == -1 {
+				idx = len(rest.text)
+			}
+			field = rest.cut(0, idx)
+			rest = rest.cut(idx, len(rest.text))
+		}
+
+		if err := decodeValue(v.Field(i), field.trim(), f.Tag, 0); err != nil {
+			if !strings.HasPrefix(err.Msg, f.Name+":") {
+				err.Msg = f.Name + ": " + err.Msg
+			}
+			return err
+		}
+		rest = rest.trim()
+	}
+
+	if rest.text != "" {
+		return rest.errorf("unexpected trailing text %q", preview(rest.text))
+	}
+	return nil
+}
+
+// splitList splits on sep, or on whitespace without one. Empty text is an
+// empty list.
+func splitList(p piece, sep string) (parts []piece) {
+	if sep == "" {
+		start := -1
+		for i := 0; i <= len(p.text); i++ {
+			isSpace := i == len(p.text) || p.text[i] == ' ' || p.text[i] == '\t'
+			if !isSpace && start == -1 {
+				start = i
+			} else if isSpace && start != -1 {
+				parts = append(parts, p.cut(start, i))
+				start = -1
+			}
+		}
+		return parts
+	}
+
+	if strings.TrimSpace(p.text) == "" {
+		return nil
+	}
+	start := 0
+	for {
+		idx := strings.Index(p.text[start:], sep)
+		if idx == -1 {
+			return append(parts, p.cut(start, len(p.text)))
+		}
+		parts = append(parts, p.cut(start, start+idx))
+		start += idx + len(sep)
+	}
+}
+
+func sepKey(depth int) string {
+	if depth == 0 {
+		return "sep"
+	}
+	return "sep" + strconv.Itoa(depth+1)
+}
+
+func skipField(f reflect.StructField) bool {
+	return !f.IsExported() || f.Tag.Get("scan") == "-"
+}
+
+func lastField(t reflect.Type) int {
+	for i := t.NumField() - 1; i >= 0; i-- {
+		if !skipField(t.Field(i)) {
+			return i
+		}
+	}
+	return -1
+}
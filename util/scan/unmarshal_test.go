@@ -0,0 +1,65 @@
+package scan
+
+import (
+	"reflect"
+	"testing"
+)
+
+type cubes struct {
+	Count int
+	Color string
+}
+
+type game struct {
+	ID    int       `prefix:"Game" end:":"`
+	Draws [][]cubes `sep:";" sep2:","`
+}
+
+func TestUnmarshal(t *testing.T) {
+	var games []game
+	err := Unmarshal("Game 1: 3 blue, 4 red; 1 red, 2 green\nGame 12: 8 green", &games)
+	want := []game{
+		{1, [][]cubes{{{3, "blue"}, {4, "red"}}, {{1, "red"}, {2, "green"}}}},
+		{12, [][]cubes{{{8, "green"}}}},
+	}
+	if err != nil || !reflect.DeepEqual(games, want) {
+		t.Errorf("Unmarshal() = %+v, %v", games, err)
+	}
+
+	type hand struct {
+		Cards string
+		Bid   uint
+		note  string
+	}
+	var hands []*hand
+	err = Unmarshal("32T3K 765\nKK677  28", &hands)
+	if err != nil || len(hands) != 2 || *hands[1] != (hand{"KK677", 28, ""}) {
+		t.Errorf("Unmarshal() = %+v, %v", hands, err)
+	}
+
+	var rows [][]int
+	err = Unmarshal("0 3 6\n-1 2", &rows)
+	if err != nil || !reflect.DeepEqual(rows, [][]int{{0, 3, 6}, {-1, 2}}) {
+		t.Errorf("Unmarshal() = %v, %v", rows, err)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"prefix", "Game 1: 3 blue\nGane 2: 1 red", "line 2, column 1: ID: expected \"Game\", found \"Gane 2: 1 red\"\n\tGane 2: 1 red\n\t^"},
+		{"end", "Game 1 3 blue", "line 1, column 6: ID: expected \":\" after it, found \"1 3 blue\"\n\tGame 1 3 blue\n\t     ^"},
+		{"nested", "Game 1: 3 blue; 4 red, x green", "line 1, column 24: Draws: Count: parsing \"x\" as int64: strconv.ParseInt: parsing \"x\": invalid syntax\n\tGame 1: 3 blue; 4 red, x green\n\t                       ^"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var games []game
+			if err := Unmarshal(tt.input, &games); err == nil || err.Error() != tt.want {
+				t.Errorf("Unmarshal() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package search finds cheapest paths through graphs that are only known by
+// their neighbours, so a state can be anything comparable: a point, or a
+// point with a heading and how far it's gone straight.
+package search
+
+import "container/heap"
+
+// Edge is a step to a neighbouring state and what it costs, which mustn't be
+// negative.
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Dijkstra finds the cheapest path from any of starts to a state goal accepts,
+// expanding states with neighbors. It returns the states along the path, from
+// its start to the goal, and the path's cost, or false if no goal is
+// reachable.
+func Dijkstra[S comparable](starts []S, neighbors func(S) []Edge[S], goal func(S) bool) (path []S, cost int, ok bool) {
+	dist := make(map[S]int)
+	prev := make(map[S]S)
+	queue := &frontier[S]{}
+	for _, start := range starts {
+		dist[start] = 0
+		heap.Push(queue, item[S]{start, 0})
+	}
+
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(item[S])
+		if current.cost > dist[current.state] {
+			// already reached more cheaply, this is a stale entry
+			continue
+		}
+		if goal(current.state) {
+			return walkBack(prev, current.state), current.cost, true
+		}
+
+		for _, edge := range neighbors(current.state) {
+			next := current.cost + edge.Cost
+			if known, seen := dist[edge.To]; seen && known <= next {
+				continue
+			}
+			dist[edge.To] = next
+			prev[edge.To] = current.state
+			heap.Push(queue, item[S]{edge.To, next})
+		}
+	}
+	return nil, 0, false
+}
+
+// walkBack follows prev from end back to its start, which has no prev as
+// nothing is cheaper to reach than a start
+func walkBack[S comparable](prev map[S]S, end S) []S {
+	path := []S{end}
+	for state, ok := prev[end]; ok; state, ok = prev[state] {
+		path = append(path, state)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+type item[S comparable] struct {
+	state S
+	cost  int
+}
+
+// frontier is a min-heap of states by cost for container/heap
+type frontier[S comparable] []item[S]
+
+func (f frontier[S]) Len() int           { return len(f) }
+func (f frontier[S]) Less(i, j int) bool { return f[i].cost < f[j].cost }
+func (f frontier[S]) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f *frontier[S]) Push(x any)        { *f = append(*f, x.(item[S])) }
+
+func (f *frontier[S]) Pop() any {
+	old := *f
+	last := old[len(old)-1]
+	*f = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,42 @@
+package search
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDijkstra(t *testing.T) {
+	// a -1- b -1- c -1- d, with a shortcut a -5- d and e on its own
+	graph := map[string][]Edge[string]{
+		"a": {{"b", 1}, {"d", 5}},
+		"b": {{"a", 1}, {"c", 1}},
+		"c": {{"b", 1}, {"d", 1}},
+		"d": {{"c", 1}, {"a", 5}},
+	}
+	neighbors := func(s string) []Edge[string] { return graph[s] }
+	is := func(want string) func(string) bool {
+		return func(s string) bool { return s == want }
+	}
+
+	tests := []struct {
+		name     string
+		starts   []string
+		goal     string
+		wantPath []string
+		wantCost int
+		wantOK   bool
+	}{
+		{"around the shortcut", []string{"a"}, "d", []string{"a", "b", "c", "d"}, 3, true},
+		{"start is the goal", []string{"c"}, "c", []string{"c"}, 0, true},
+		{"nearest start", []string{"a", "c"}, "d", []string{"c", "d"}, 1, true},
+		{"unreachable", []string{"a"}, "e", nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, cost, ok := Dijkstra(tt.starts, neighbors, is(tt.goal))
+			if fmt.Sprint(path) != fmt.Sprint(tt.wantPath) || cost != tt.wantCost || ok != tt.wantOK {
+				t.Errorf("Dijkstra() = %v, %d, %v, want %v, %d, %v", path, cost, ok, tt.wantPath, tt.wantCost, tt.wantOK)
+			}
+		})
+	}
+}
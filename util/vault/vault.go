@@ -0,0 +1,216 @@
+// Package vault encrypts puzzle inputs so they can be committed, since AOC
+// asks for inputs not to be published. Each input.txt is stored next to it as
+// input.txt.enc, encrypted with AES-GCM under a key derived from the
+// passphrase in $AOC_VAULT_KEY.
+//
+// Days embed whichever of the two files they have and read them with Input,
+// which decrypts when there's no plain input.txt:
+//
+//	//go:embed input.txt*
+//	var inputFiles embed.FS
+//
+// Every day also commits input.txt.placeholder, so the pattern matches
+// something in a fresh clone that has neither.
+package vault
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+const (
+	// KeyEnv is the env var holding the passphrase
+	KeyEnv = "AOC_VAULT_KEY"
+	// Ext is added to the name of an encrypted file
+	Ext = ".enc"
+)
+
+var (
+	// ErrLocked is returned when there's only an encrypted input and no key
+	ErrLocked = errors.New("input is encrypted and " + KeyEnv + " isn't set")
+	// ErrMissing is returned when there's neither a plain nor an encrypted
+	// input
+	ErrMissing = errors.New("input is missing")
+	// ErrWrongKey is returned when decryption fails, which is almost always
+	// the wrong passphrase rather than a corrupted file
+	ErrWrongKey = errors.New("can't decrypt input, is " + KeyEnv + " right?")
+
+	errNotVault = errors.New("not an encrypted input")
+)
+
+// magic starts every encrypted file, and is authenticated with it
+var magic = []byte("aocvault1")
+
+const (
+	saltSize   = 16
+	iterations = 100_000
+	keySize    = 32
+)
+
+// Encrypt seals plaintext with a key derived from passphrase and a new random
+// salt. The output is magic, salt, nonce then ciphertext.
+func Encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append(append(append([]byte{}, magic...), salt...), nonce...)
+	return gcm.Seal(out, nonce, plaintext, magic), nil
+}
+
+// Decrypt opens what Encrypt sealed.
+func Decrypt(sealed []byte, passphrase string) ([]byte, error) {
+	if !bytes.HasPrefix(sealed, magic) {
+		return nil, errNotVault
+	}
+	sealed = sealed[len(magic):]
+	if len(sealed) < saltSize {
+		return nil, errNotVault
+	}
+	salt, sealed := sealed[:saltSize], sealed[saltSize:]
+
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errNotVault
+	}
+	nonce, sealed := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, sealed, magic)
+	if err != nil {
+		return nil, ErrWrongKey
+	}
+	return plaintext, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, errors.New("empty passphrase")
+	}
+	block, err := aes.NewCipher(pbkdf2([]byte(passphrase), salt, iterations, keySize))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2 is PBKDF2 with HMAC-SHA256 from RFC 8018, here since the standard
+// library only has it from Go 1.24
+func pbkdf2(password, salt []byte, iter, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	var key []byte
+	for block := uint32(1); len(key) < keyLen; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(binary.BigEndian.AppendUint32(nil, block))
+		u := prf.Sum(nil)
+
+		t := append([]byte{}, u...)
+		for i := 1; i < iter; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
+
+// ReadInput returns input.txt from fsys, or decrypts input.txt.enc with the
+// passphrase in $AOC_VAULT_KEY if there's no plain one.
+func ReadInput(fsys fs.FS) (string, error) {
+	return ReadFile(fsys, "input.txt")
+}
+
+// ReadFile is ReadInput for any file, like a named input in inputs/.
+func ReadFile(fsys fs.FS, name string) (string, error) {
+	if plain, err := fs.ReadFile(fsys, name); err == nil {
+		return string(plain), nil
+	}
+
+	sealed, err := fs.ReadFile(fsys, name+Ext)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("no %s or %s%s: %w", name, name, Ext, ErrMissing)
+	}
+	if err != nil {
+		return "", err
+	}
+	passphrase := os.Getenv(KeyEnv)
+	if passphrase == "" {
+		return "", ErrLocked
+	}
+	plain, err := Decrypt(sealed, passphrase)
+	return string(plain), err
+}
+
+// Input is ReadInput for a day's init. A locked or missing input is "" so
+// the example tests still run: the runner won't run a part on an empty
+// input, and tests of the actual input skip with vaulttest.SkipIfLocked. It
+// panics on any other error, like the wrong key.
+func Input(fsys fs.FS) string {
+	input, err := ReadInput(fsys)
+	if errors.Is(err, ErrLocked) || errors.Is(err, ErrMissing) {
+		return ""
+	}
+	if err != nil {
+		panic(err)
+	}
+	return input
+}
+
+// LockFile encrypts filename to filename.enc. An existing .enc that already
+// holds the same input is left alone so it doesn't change in git every time.
+func LockFile(filename, passphrase string) (changed bool, err error) {
+	plain, err := os.ReadFile(filename)
+	if err != nil {
+		return false, err
+	}
+
+	if sealed, err := os.ReadFile(filename + Ext); err == nil {
+		if old, err := Decrypt(sealed, passphrase); err == nil && bytes.Equal(old, plain) {
+			return false, nil
+		}
+	}
+
+	sealed, err := Encrypt(plain, passphrase)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(filename+Ext, sealed, 0644)
+}
+
+// UnlockFile decrypts filename.enc back to filename.
+func UnlockFile(filename, passphrase string) error {
+	sealed, err := os.ReadFile(filename + Ext)
+	if err != nil {
+		return err
+	}
+	plain, err := Decrypt(sealed, passphrase)
+	if err != nil {
+		return fmt.Errorf("%s: %w", filename+Ext, err)
+	}
+	return os.WriteFile(filename, plain, 0644)
+}
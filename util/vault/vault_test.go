@@ -0,0 +1,143 @@
+package vault
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestPBKDF2(t *testing.T) {
+	// RFC 7914 section 11
+	want := "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc" +
+		"49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"
+	got := hex.EncodeToString(pbkdf2([]byte("passwd"), []byte("salt"), 1, 64))
+	if got != want {
+		t.Errorf("pbkdf2() = %s, want %s", got, want)
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	plain := []byte("467..114..\n...*......\n")
+	sealed, err := Encrypt(plain, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Decrypt(sealed, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(plain) {
+		t.Errorf("Decrypt() = %q, want %q", got, plain)
+	}
+
+	if _, err := Decrypt(sealed, "hunter3"); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("Decrypt() with the wrong key = %v, want ErrWrongKey", err)
+	}
+
+	tampered := append([]byte{}, sealed...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := Decrypt(tampered, "hunter2"); err == nil {
+		t.Error("Decrypt() of a tampered file should error")
+	}
+
+	if _, err := Decrypt([]byte("plain text"), "hunter2"); err == nil {
+		t.Error("Decrypt() of a file that isn't encrypted should error")
+	}
+}
+
+func TestReadInput(t *testing.T) {
+	sealed, err := Encrypt([]byte("secret input"), "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		key     string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "plain input wins",
+			files: fstest.MapFS{"input.txt": {Data: []byte("plain")}, "input.txt.enc": {Data: sealed}},
+			want:  "plain",
+		},
+		{
+			name:  "decrypts with key",
+			files: fstest.MapFS{"input.txt.enc": {Data: sealed}},
+			key:   "hunter2",
+			want:  "secret input",
+		},
+		{
+			name:    "locked without key",
+			files:   fstest.MapFS{"input.txt.enc": {Data: sealed}},
+			wantErr: ErrLocked,
+		},
+		{
+			name:    "missing",
+			files:   fstest.MapFS{"input.txt.placeholder": {Data: []byte("put your input in input.txt")}},
+			wantErr: ErrMissing,
+		},
+		{
+			name:    "wrong key",
+			files:   fstest.MapFS{"input.txt.enc": {Data: sealed}},
+			key:     "hunter3",
+			wantErr: ErrWrongKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(KeyEnv, tt.key)
+			got, err := ReadInput(tt.files)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadInput() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReadInput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("locked or missing", func(t *testing.T) {
+		t.Setenv(KeyEnv, "")
+		if got := Input(fstest.MapFS{"input.txt.enc": {Data: sealed}}); got != "" {
+			t.Errorf("Input() = %q, want empty", got)
+		}
+		if got := Input(fstest.MapFS{"input.txt.placeholder": {}}); got != "" {
+			t.Errorf("Input() = %q, want empty", got)
+		}
+	})
+}
+
+func TestLockFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "input.txt")
+	os.WriteFile(filename, []byte("1\n2\n3\n"), 0644)
+
+	changed, err := LockFile(filename, "hunter2")
+	if err != nil || !changed {
+		t.Fatalf("LockFile() = %v, %v, want a new file", changed, err)
+	}
+	first, _ := os.ReadFile(filename + Ext)
+
+	// the same input mustn't be encrypted again with a new salt
+	changed, err = LockFile(filename, "hunter2")
+	if err != nil || changed {
+		t.Fatalf("LockFile() of the same input = %v, %v, want unchanged", changed, err)
+	}
+	if again, _ := os.ReadFile(filename + Ext); string(again) != string(first) {
+		t.Error("LockFile() rewrote an unchanged input")
+	}
+
+	os.Remove(filename)
+	if err := UnlockFile(filename, "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(filename); string(got) != "1\n2\n3\n" {
+		t.Errorf("UnlockFile() wrote %q", got)
+	}
+}
@@ -0,0 +1,18 @@
+// Package vaulttest helps tests of days whose input may be locked in the
+// vault, kept apart so the days' binaries don't import testing.
+package vaulttest
+
+import (
+	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/vault"
+)
+
+// SkipIfLocked skips a test case whose input is empty, which is what
+// vault.Input gives for the actual input when it's locked or missing.
+func SkipIfLocked(t testing.TB, input string) {
+	t.Helper()
+	if input == "" {
+		t.Skipf("%v, or %v", vault.ErrLocked, vault.ErrMissing)
+	}
+}
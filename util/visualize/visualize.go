@@ -0,0 +1,208 @@
+// Package visualize records grid simulations as animated GIFs or PNG images,
+// using only the standard library so it works offline.
+//
+// Solutions keep a package level Hook that's nil unless main sets it, and call
+// it after each step:
+//
+//	var hook visualize.Hook
+//	...
+//	if hook != nil {
+//		hook.Frame(g, highlighted)
+//	}
+package visualize
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/grid"
+)
+
+// Hook receives a frame each time a simulation takes a step.
+type Hook interface {
+	// Frame is called with the current grid and the cells to highlight, like
+	// energized tiles. Both may be changed once Frame returns.
+	Frame(g *grid.Grid, highlight map[grid.Point]bool)
+}
+
+// Options configure a Recorder, zero values get defaults.
+type Options struct {
+	// Palette colours cells by their character, others use Default
+	Palette map[byte]color.Color
+	// Default is the colour of cells missing from Palette, black by default
+	Default color.Color
+	// Highlight is the colour of highlighted cells, yellow by default
+	Highlight color.Color
+	// CellSize is the width and height of a cell in pixels, 4 by default
+	CellSize int
+	// Skip records only every Skip-th frame, the first frame is always kept
+	Skip int
+	// Delay is the time between GIF frames in 100ths of a second, 5 by default
+	Delay int
+}
+
+// Recorder is a Hook that keeps frames in memory to write out at the end.
+// It's safe to call from several goroutines, but frames from concurrent
+// simulations will be interleaved.
+type Recorder struct {
+	opts    Options
+	palette color.Palette
+	indexes map[byte]uint8
+
+	mu     sync.Mutex
+	frames []*image.Paletted
+	calls  int
+}
+
+var errNoFrames = errors.New("no frames recorded")
+
+const (
+	defaultIndex   = 0
+	highlightIndex = 1
+)
+
+func NewRecorder(opts Options) *Recorder {
+	if opts.Default == nil {
+		opts.Default = color.Black
+	}
+	if opts.Highlight == nil {
+		opts.Highlight = color.RGBA{R: 0xff, G: 0xd7, A: 0xff}
+	}
+	if opts.CellSize <= 0 {
+		opts.CellSize = 4
+	}
+	if opts.Skip <= 0 {
+		opts.Skip = 1
+	}
+	if opts.Delay <= 0 {
+		opts.Delay = 5
+	}
+
+	r := &Recorder{
+		opts:    opts,
+		palette: color.Palette{opts.Default, opts.Highlight},
+		indexes: make(map[byte]uint8),
+	}
+
+	// sorted so the same options always give the same palette
+	cells := make([]byte, 0, len(opts.Palette))
+	for cell := range opts.Palette {
+		cells = append(cells, cell)
+	}
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+	for _, cell := range cells {
+		if len(r.palette) == 256 {
+			break
+		}
+		r.indexes[cell] = uint8(len(r.palette))
+		r.palette = append(r.palette, opts.Palette[cell])
+	}
+	return r
+}
+
+func (r *Recorder) Frame(g *grid.Grid, highlight map[grid.Point]bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls++
+	if (r.calls-1)%r.opts.Skip != 0 {
+		return
+	}
+	r.frames = append(r.frames, r.draw(g, highlight))
+}
+
+// Frames returns how many frames have been kept.
+func (r *Recorder) Frames() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.frames)
+}
+
+func (r *Recorder) draw(g *grid.Grid, highlight map[grid.Point]bool) *image.Paletted {
+	size := r.opts.CellSize
+	img := image.NewPaletted(image.Rect(0, 0, g.Width*size, g.Height*size), r.palette)
+
+	for y, row := range g.Cells {
+		for x, cell := range row {
+			idx, ok := r.indexes[cell]
+			if !ok {
+				idx = defaultIndex
+			}
+			if highlight[grid.Point{X: x, Y: y}] {
+				idx = highlightIndex
+			}
+			if idx == defaultIndex {
+				continue // NewPaletted is already filled with index 0
+			}
+
+			for py := y * size; py < (y+1)*size; py++ {
+				for px := x * size; px < (x+1)*size; px++ {
+					img.SetColorIndex(px, py, idx)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// WriteGIF writes every kept frame as a looping animation.
+func (r *Recorder) WriteGIF(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.frames) == 0 {
+		return errNoFrames
+	}
+	anim := &gif.GIF{Image: r.frames}
+	for range r.frames {
+		anim.Delay = append(anim.Delay, r.opts.Delay)
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// WritePNG writes the last frame as a still image.
+func (r *Recorder) WritePNG(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.frames) == 0 {
+		return errNoFrames
+	}
+	return png.Encode(w, r.frames[len(r.frames)-1])
+}
+
+// Save writes a GIF or PNG to filename, picked by its extension. Nothing's
+// written if no frames were recorded.
+func (r *Recorder) Save(filename string) error {
+	if r.Frames() == 0 {
+		return errNoFrames
+	}
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if isPNG(filename) {
+		err = r.WritePNG(file)
+	} else {
+		err = r.WriteGIF(file)
+	}
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func isPNG(filename string) bool {
+	return strings.EqualFold(filepath.Ext(filename), ".png")
+}
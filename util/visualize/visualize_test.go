@@ -0,0 +1,76 @@
+package visualize
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Kris-Pelteshki/aoc_2023/util/grid"
+)
+
+func TestRecorder(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	r := NewRecorder(Options{Palette: map[byte]color.Color{'#': red}, CellSize: 2, Skip: 2})
+
+	g := grid.Parse("#.\n..")
+	for i := 0; i < 5; i++ {
+		r.Frame(g, map[grid.Point]bool{{X: 1, Y: 1}: true})
+	}
+	if r.Frames() != 3 {
+		t.Fatalf("Frames() = %v, want 3", r.Frames())
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteGIF(&buf); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != 3 || anim.Image[0].Bounds().Dx() != 4 {
+		t.Errorf("gif has %d frames of width %d, want 3 of width 4", len(anim.Image), anim.Image[0].Bounds().Dx())
+	}
+
+	buf.Reset()
+	if err := r.WritePNG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := color.RGBAModel.Convert(img.At(1, 1)); got != red {
+		t.Errorf("cell # = %v, want %v", got, red)
+	}
+	if got := color.RGBAModel.Convert(img.At(3, 3)); got != (color.RGBA{R: 0xff, G: 0xd7, A: 0xff}) {
+		t.Errorf("highlighted cell = %v, want the default highlight", got)
+	}
+	if got := color.RGBAModel.Convert(img.At(2, 0)); got != (color.RGBA{A: 0xff}) {
+		t.Errorf("empty cell = %v, want black", got)
+	}
+}
+
+func TestRecorder_noFrames(t *testing.T) {
+	r := NewRecorder(Options{})
+	var buf bytes.Buffer
+	if err := r.WriteGIF(&buf); !errors.Is(err, errNoFrames) {
+		t.Errorf("WriteGIF() = %v, want %v", err, errNoFrames)
+	}
+	if err := r.WritePNG(&buf); !errors.Is(err, errNoFrames) {
+		t.Errorf("WritePNG() = %v, want %v", err, errNoFrames)
+	}
+
+	filename := filepath.Join(t.TempDir(), "day14.gif")
+	if err := r.Save(filename); !errors.Is(err, errNoFrames) {
+		t.Errorf("Save() = %v, want %v", err, errNoFrames)
+	}
+	if _, err := os.Stat(filename); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Save() left a file behind: %v", err)
+	}
+}